@@ -0,0 +1,8 @@
+package ordered
+
+// Reverse returns a comparison function which reverses the order produced by
+// cmp. For example, Reverse([cmp.Compare]) can be used with NewMap to create a
+// Map which orders its keys from largest to smallest.
+func Reverse[K any](cmp func(a, b K) int) func(a, b K) int {
+	return func(a, b K) int { return cmp(b, a) }
+}
@@ -0,0 +1,25 @@
+package ordered_test
+
+import (
+	stdcmp "cmp"
+	"fmt"
+
+	"github.com/mdlayher/ordered"
+)
+
+func ExampleReverse() {
+	// Create a map which orders its keys by descending lexical comparison.
+	m := ordered.NewMap[string, int](ordered.Reverse(stdcmp.Compare[string]))
+	m.Set("foo", 1)
+	m.Set("bar", 2)
+	m.Set("baz", 3)
+
+	for _, kv := range m.Range() {
+		fmt.Printf("- %s: %d\n", kv.Key, kv.Value)
+	}
+
+	// Output:
+	// - foo: 1
+	// - baz: 3
+	// - bar: 2
+}
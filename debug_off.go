@@ -0,0 +1,7 @@
+//go:build !ordereddebug
+
+package ordered
+
+// debug enables expensive invariant checks. Build with -tags ordereddebug to
+// enable them.
+const debug = false
@@ -0,0 +1,33 @@
+//go:build !ordereddebug
+
+package ordered_test
+
+import (
+	stdcmp "cmp"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/mdlayher/ordered"
+)
+
+func TestMapFloatKeysDuplicateNaN(t *testing.T) {
+	// NaN is never equal to itself, so each Set of NaN stores a new key. The
+	// ordereddebug checks reject this as an inconsistent comparison.
+	nan := math.NaN()
+
+	m := ordered.NewMap[float64, int](stdcmp.Compare)
+	m.Set(0, 0)
+	m.Set(nan, 1)
+	m.Set(nan, 2)
+
+	if diff := cmp.Diff(3, m.Len()); diff != "" {
+		t.Fatalf("unexpected length (-want +got):\n%s", diff)
+	}
+
+	want := []float64{nan, nan, 0}
+	if diff := cmp.Diff(want, m.Keys(), cmpopts.EquateNaNs()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,7 @@
+//go:build ordereddebug
+
+package ordered
+
+// debug enables expensive invariant checks, such as verifying the order of a
+// Map's keys after every insertion.
+const debug = true
@@ -0,0 +1,62 @@
+//go:build ordereddebug
+
+package ordered_test
+
+import (
+	stdcmp "cmp"
+	"math"
+	"testing"
+
+	"github.com/mdlayher/ordered"
+)
+
+func TestMapDebugVerify(t *testing.T) {
+	tests := []struct {
+		name string
+		cmp  func(a, b int) int
+		fn   func(m *ordered.Map[int, int])
+	}{
+		{
+			name: "distinct keys equal",
+			cmp:  func(a, b int) int { return stdcmp.Compare(a/10, b/10) },
+			fn: func(m *ordered.Map[int, int]) {
+				m.Set(1, 1)
+				m.Set(2, 2)
+			},
+		},
+		{
+			name: "inconsistent set many",
+			cmp:  func(_, _ int) int { return 1 },
+			fn: func(m *ordered.Map[int, int]) {
+				m.SetMany(
+					ordered.KeyValue[int, int]{Key: 1},
+					ordered.KeyValue[int, int]{Key: 2},
+					ordered.KeyValue[int, int]{Key: 3},
+				)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := ordered.NewMap[int, int](tt.cmp)
+			if !panics(t, func() { tt.fn(m) }) {
+				t.Fatal("expected inconsistent comparison panic, but got none")
+			}
+		})
+	}
+
+	// NaN is never equal to itself, so storing it twice produces duplicate keys
+	// which compare as equal.
+	floats := ordered.NewMap[float64, int](stdcmp.Compare)
+	floats.Set(math.NaN(), 1)
+	if !panics(t, func() { floats.Set(math.NaN(), 2) }) {
+		t.Fatal("expected duplicate NaN panic, but got none")
+	}
+
+	// A valid comparison function passes verification.
+	m := ordered.NewMap[int, int](stdcmp.Compare)
+	for i := 0; i < 10; i++ {
+		m.Set(9-i, i)
+	}
+}
@@ -0,0 +1,394 @@
+package ordered
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// A jsonPair is the JSON representation of a KeyValue pair for Maps whose keys
+// cannot be used as JSON object keys.
+type jsonPair[K comparable, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// MarshalJSON implements json.Marshaler. If K's underlying type is string, the
+// Map is encoded as a JSON object with keys in sorted order. Otherwise, the Map
+// is encoded as a JSON array of {"key": K, "value": V} objects in sorted order.
+func (m *Map[K, V]) MarshalJSON() ([]byte, error) {
+	m.check(ro)
+
+	if !stringKeys[K]() {
+		pairs := make([]jsonPair[K, V], 0, len(m.keys))
+		for i, k := range m.keys {
+			pairs = append(pairs, jsonPair[K, V]{
+				Key:   k,
+				Value: m.vals[i],
+			})
+		}
+
+		return json.Marshal(pairs)
+	}
+
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		kb, err := json.Marshal(reflect.ValueOf(k).String())
+		if err != nil {
+			return nil, err
+		}
+
+		vb, err := json.Marshal(m.vals[i])
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(kb)
+		b.WriteByte(':')
+		b.Write(vb)
+	}
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding either form produced by
+// MarshalJSON and replacing any existing contents of the Map. If a key appears
+// more than once, the last pair wins.
+//
+// The Map must be constructed using NewMap before calling UnmarshalJSON so that
+// its keys can be sorted.
+func (m *Map[K, V]) UnmarshalJSON(b []byte) error {
+	if m == nil || m.cmp == nil {
+		return errors.New("ordered: Map.UnmarshalJSON requires a Map constructed using NewMap")
+	}
+	m.check(rw)
+
+	b = bytes.TrimSpace(b)
+	if string(b) == "null" {
+		// Like the standard library, treat null as a no-op.
+		return nil
+	}
+
+	var mm map[K]V
+	if len(b) > 0 && b[0] == '{' && stringKeys[K]() {
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(b, &obj); err != nil {
+			return err
+		}
+
+		mm = make(map[K]V, len(obj))
+		for s, raw := range obj {
+			var (
+				k K
+				v V
+			)
+
+			reflect.ValueOf(&k).Elem().SetString(s)
+			if err := json.Unmarshal(raw, &v); err != nil {
+				return err
+			}
+
+			mm[k] = v
+		}
+	} else {
+		var pairs []jsonPair[K, V]
+		if err := json.Unmarshal(b, &pairs); err != nil {
+			return err
+		}
+
+		mm = make(map[K]V, len(pairs))
+		for _, p := range pairs {
+			mm[p.Key] = p.Value
+		}
+	}
+
+	// Only replace the contents of the Map once all input is decoded.
+	m.m = mm
+	m.sortKeys()
+	return nil
+}
+
+// Value implements driver.Valuer, encoding the Map as JSON using MarshalJSON
+// so that it can be stored in a JSON database column with its keys in sorted
+// order. A nil *Map is stored as NULL, mirroring Scan.
+func (m *Map[K, V]) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	return m.MarshalJSON()
+}
+
+// Scan implements sql.Scanner, decoding a JSON []byte or string database value
+// using UnmarshalJSON. Like UnmarshalJSON, Scan replaces any existing contents
+// of the Map and requires a Map constructed using NewMap. A NULL value is
+// treated as a no-op.
+func (m *Map[K, V]) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return m.UnmarshalJSON(src)
+	case string:
+		return m.UnmarshalJSON([]byte(src))
+	default:
+		return fmt.Errorf("ordered: Map.Scan cannot decode %T", src)
+	}
+}
+
+// GobEncode implements gob.GobEncoder, encoding the Map's KeyValue pairs in
+// sorted order. The comparison function is not encoded.
+func (m *Map[K, V]) GobEncode() ([]byte, error) {
+	m.check(ro)
+
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(m.Range()); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder, replacing any existing contents of the
+// Map with the KeyValue pairs produced by GobEncode.
+//
+// Because the comparison function cannot be encoded, the Map must be
+// constructed using NewMap before calling GobDecode so that its keys can be
+// sorted.
+func (m *Map[K, V]) GobDecode(b []byte) error {
+	if m == nil || m.cmp == nil {
+		return errors.New("ordered: Map.GobDecode requires a Map constructed using NewMap")
+	}
+	m.check(rw)
+
+	var kvs []KeyValue[K, V]
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&kvs); err != nil {
+		return err
+	}
+
+	mm := make(map[K]V, len(kvs))
+	for _, kv := range kvs {
+		mm[kv.Key] = kv.Value
+	}
+
+	m.m = mm
+	m.sortKeys()
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The Map is encoded as a
+// uvarint count of KeyValue pairs followed by each key and value in sorted
+// order, each encoded using gob and prefixed with a uvarint length. The
+// comparison function is not encoded.
+func (m *Map[K, V]) MarshalBinary() ([]byte, error) {
+	m.check(ro)
+
+	var b bytes.Buffer
+	if _, err := m.WriteTo(&b); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// WriteTo implements io.WriterTo, writing the Map to w in the format produced
+// by MarshalBinary and returning the number of bytes written. WriteTo writes
+// each KeyValue pair as it is encoded, so the full encoding of the Map is never
+// held in memory.
+func (m *Map[K, V]) WriteTo(w io.Writer) (int64, error) {
+	m.check(ro)
+
+	// Reuse a single buffer for the count and each encoded pair.
+	b := binary.AppendUvarint(nil, uint64(len(m.keys)))
+	nn, err := w.Write(b)
+	n := int64(nn)
+	if err != nil {
+		return n, err
+	}
+
+	for i, k := range m.keys {
+		if b, err = appendGob(b[:0], k); err != nil {
+			return n, err
+		}
+		if b, err = appendGob(b, m.vals[i]); err != nil {
+			return n, err
+		}
+
+		nn, err = w.Write(b)
+		n += int64(nn)
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return n, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing any
+// existing contents of the Map with the KeyValue pairs produced by
+// MarshalBinary.
+//
+// Because the comparison function cannot be encoded, the Map must be
+// constructed using NewMap before calling UnmarshalBinary so that its keys can
+// be sorted.
+func (m *Map[K, V]) UnmarshalBinary(b []byte) error {
+	if m == nil || m.cmp == nil {
+		return errors.New("ordered: Map.UnmarshalBinary requires a Map constructed using NewMap")
+	}
+	m.check(rw)
+
+	r := bytes.NewReader(b)
+	mm, err := m.readBinary(&binaryReader{r: r})
+	if err != nil {
+		return err
+	}
+	if r.Len() > 0 {
+		return fmt.Errorf("ordered: malformed binary Map: %d trailing bytes", r.Len())
+	}
+
+	m.m = mm
+	m.sortKeys()
+	return nil
+}
+
+// ReadFrom replaces any existing contents of the Map with the KeyValue pairs
+// read from r in the format produced by WriteTo and MarshalBinary, and returns
+// the number of bytes read.
+//
+// ReadFrom satisfies the io.ReaderFrom interface, but does not follow its
+// contract of reading until EOF: ReadFrom reads exactly the bytes of one
+// encoded Map from r and stops, so r may contain further data.
+//
+// Like UnmarshalBinary, the Map must be constructed using NewMap before calling
+// ReadFrom so that its keys can be sorted.
+func (m *Map[K, V]) ReadFrom(r io.Reader) (int64, error) {
+	if m == nil || m.cmp == nil {
+		return 0, errors.New("ordered: Map.ReadFrom requires a Map constructed using NewMap")
+	}
+	m.check(rw)
+
+	br := &binaryReader{r: r}
+	mm, err := m.readBinary(br)
+	if err != nil {
+		return br.n, err
+	}
+
+	m.m = mm
+	m.sortKeys()
+	return br.n, nil
+}
+
+// readBinary decodes the binary format produced by MarshalBinary from br into
+// a new map, so that the Map is only modified once all input is decoded.
+func (m *Map[K, V]) readBinary(br *binaryReader) (map[K]V, error) {
+	n, err := readUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+
+	// The count is untrusted, so limit the initial allocation.
+	mm := make(map[K]V, min(n, 1024))
+	for i := uint64(0); i < n; i++ {
+		var (
+			k K
+			v V
+		)
+
+		if err := readGob(br, &k); err != nil {
+			return nil, err
+		}
+		if err := readGob(br, &v); err != nil {
+			return nil, err
+		}
+
+		mm[k] = v
+	}
+
+	return mm, nil
+}
+
+// appendGob appends the length-prefixed gob encoding of v to b.
+func appendGob(b []byte, v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+
+	b = binary.AppendUvarint(b, uint64(buf.Len()))
+	return append(b, buf.Bytes()...), nil
+}
+
+// readGob decodes a length-prefixed gob encoding from br into v.
+func readGob(br *binaryReader, v any) error {
+	n, err := readUvarint(br)
+	if err != nil {
+		return err
+	}
+
+	// Read incrementally rather than trusting n for a single allocation.
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(io.LimitReader(br, int64(n))); err != nil {
+		return fmt.Errorf("ordered: malformed binary Map: %w", err)
+	}
+	if uint64(buf.Len()) != n {
+		return fmt.Errorf("ordered: malformed binary Map: %d byte element exceeds %d bytes of input: %w", n, buf.Len(), io.ErrUnexpectedEOF)
+	}
+
+	if err := gob.NewDecoder(&buf).Decode(v); err != nil {
+		return fmt.Errorf("ordered: malformed binary Map: %w", err)
+	}
+
+	return nil
+}
+
+// readUvarint decodes a uvarint from br.
+func readUvarint(br *binaryReader) (uint64, error) {
+	n, err := binary.ReadUvarint(br)
+	if err != nil {
+		return 0, fmt.Errorf("ordered: malformed binary Map: invalid uvarint: %w", err)
+	}
+
+	return n, nil
+}
+
+// A binaryReader is an io.ByteReader which counts the bytes read from r. It
+// reads one byte at a time for uvarints so that no bytes beyond the end of an
+// encoded Map are consumed from r.
+type binaryReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read implements io.Reader.
+func (br *binaryReader) Read(b []byte) (int, error) {
+	n, err := br.r.Read(b)
+	br.n += int64(n)
+	return n, err
+}
+
+// ReadByte implements io.ByteReader.
+func (br *binaryReader) ReadByte() (byte, error) {
+	var b [1]byte
+	if _, err := io.ReadFull(br, b[:]); err != nil {
+		return 0, err
+	}
+
+	return b[0], nil
+}
+
+// stringKeys reports whether K's underlying type is string.
+func stringKeys[K comparable]() bool {
+	return reflect.TypeOf((*K)(nil)).Elem().Kind() == reflect.String
+}
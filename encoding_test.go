@@ -0,0 +1,358 @@
+package ordered_test
+
+import (
+	"bytes"
+	stdcmp "cmp"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mdlayher/ordered"
+)
+
+func TestMapMarshalJSON(t *testing.T) {
+	type key string
+
+	strs := ordered.NewMap[key, int](stdcmp.Compare)
+	strs.Set("foo", 1)
+	strs.Set("bar", 2)
+	strs.Set("baz", 3)
+
+	ints := ordered.NewMap[int, string](stdcmp.Compare)
+	ints.Set(2, "two")
+	ints.Set(1, "one")
+
+	tests := []struct {
+		name string
+		m    json.Marshaler
+		want string
+	}{
+		{
+			name: "strings",
+			m:    strs,
+			want: `{"bar":2,"baz":3,"foo":1}`,
+		},
+		{
+			name: "empty strings",
+			m:    ordered.NewMap[string, int](stdcmp.Compare),
+			want: `{}`,
+		},
+		{
+			name: "ints",
+			m:    ints,
+			want: `[{"key":1,"value":"one"},{"key":2,"value":"two"}]`,
+		},
+		{
+			name: "empty ints",
+			m:    ordered.NewMap[int, string](stdcmp.Compare),
+			want: `[]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.m)
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(b)); diff != "" {
+				t.Fatalf("unexpected JSON (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMapUnmarshalJSON(t *testing.T) {
+	t.Run("strings", func(t *testing.T) {
+		m := ordered.NewMap[string, int](stdcmp.Compare)
+		m.Set("notfound", 0)
+
+		if err := json.Unmarshal([]byte(`{"foo":1,"bar":20,"baz":3,"bar":2}`), m); err != nil {
+			t.Fatalf("failed to unmarshal: %v", err)
+		}
+
+		if diff := cmp.Diff(testMap().Range(), m.Range()); diff != "" {
+			t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ints", func(t *testing.T) {
+		want := ordered.NewMap[int, string](stdcmp.Compare)
+		want.Set(2, "two")
+		want.Set(1, "one")
+
+		b, err := json.Marshal(want)
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+
+		got := ordered.NewMap[int, string](stdcmp.Compare)
+		if err := json.Unmarshal(b, got); err != nil {
+			t.Fatalf("failed to unmarshal: %v", err)
+		}
+
+		if diff := cmp.Diff(want.Range(), got.Range()); diff != "" {
+			t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var m ordered.Map[string, int]
+		if err := json.Unmarshal([]byte(`{"foo":1}`), &m); err == nil {
+			t.Fatal("expected zero Map error, but none occurred")
+		}
+
+		ints := ordered.NewMap[int, string](stdcmp.Compare)
+		if err := json.Unmarshal([]byte(`{"1":"one"}`), ints); err == nil {
+			t.Fatal("expected object into int keys error, but none occurred")
+		}
+	})
+}
+
+func TestMapSQL(t *testing.T) {
+	v, err := testMap().Value()
+	if err != nil {
+		t.Fatalf("failed to produce value: %v", err)
+	}
+
+	if diff := cmp.Diff([]byte(`{"bar":2,"baz":3,"foo":1}`), v); diff != "" {
+		t.Fatalf("unexpected value (-want +got):\n%s", diff)
+	}
+
+	for _, src := range []any{v, string(v.([]byte))} {
+		m := ordered.NewMap[string, int](stdcmp.Compare)
+		m.Set("notfound", 0)
+
+		if err := m.Scan(src); err != nil {
+			t.Fatalf("failed to scan %T: %v", src, err)
+		}
+
+		if diff := cmp.Diff(testMap().Range(), m.Range()); diff != "" {
+			t.Fatalf("unexpected pairs for %T (-want +got):\n%s", src, diff)
+		}
+	}
+
+	t.Run("NULL", func(t *testing.T) {
+		var m *ordered.Map[string, int]
+		v, err := m.Value()
+		if err != nil {
+			t.Fatalf("failed to produce NULL value: %v", err)
+		}
+		if v != nil {
+			t.Fatalf("expected NULL value, but got: %v", v)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		// NULL is a no-op.
+		m := testMap()
+		if err := m.Scan(nil); err != nil {
+			t.Fatalf("failed to scan NULL: %v", err)
+		}
+		if diff := cmp.Diff(testMap().Range(), m.Range()); diff != "" {
+			t.Fatalf("unexpected pairs after NULL (-want +got):\n%s", diff)
+		}
+
+		if err := m.Scan(1); err == nil {
+			t.Fatal("expected unsupported type error, but none occurred")
+		}
+
+		var zero ordered.Map[string, int]
+		if err := zero.Scan(v); err == nil {
+			t.Fatal("expected zero Map error, but none occurred")
+		}
+	})
+}
+
+func TestMapGob(t *testing.T) {
+	// Order by descending keys to verify the decoder's comparison function is
+	// used.
+	desc := func(a, b string) int { return stdcmp.Compare(b, a) }
+
+	want := ordered.NewMap[string, int](desc)
+	want.Set("foo", 1)
+	want.Set("bar", 2)
+	want.Set("baz", 3)
+
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(want); err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+
+	got := ordered.NewMap[string, int](desc)
+	if err := gob.NewDecoder(&b).Decode(got); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+
+	if diff := cmp.Diff(want.Range(), got.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+
+	var m ordered.Map[string, int]
+	if err := m.GobDecode(nil); err == nil {
+		t.Fatal("expected zero Map error, but none occurred")
+	}
+}
+
+func TestMapBinary(t *testing.T) {
+	want := testMap()
+	b, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	got := ordered.NewMap[string, int](stdcmp.Compare)
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if diff := cmp.Diff(want.Range(), got.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{name: "empty", b: nil},
+		{name: "bad count", b: []byte{0xff}},
+		{name: "short", b: b[:len(b)-1]},
+		{name: "trailing", b: append(b, 0x00)},
+		{name: "bad gob", b: []byte{0x01, 0x01, 0xff, 0x01, 0xff}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := ordered.NewMap[string, int](stdcmp.Compare)
+			if err := m.UnmarshalBinary(tt.b); err == nil {
+				t.Fatal("expected malformed input error, but none occurred")
+			}
+		})
+	}
+
+	var m ordered.Map[string, int]
+	if err := m.UnmarshalBinary(b); err == nil {
+		t.Fatal("expected zero Map error, but none occurred")
+	}
+}
+
+func TestMapWriteTo(t *testing.T) {
+	m := testMap()
+	want, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	var b bytes.Buffer
+	n, err := m.WriteTo(&b)
+	if err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+
+	if diff := cmp.Diff(int64(len(want)), n); diff != "" {
+		t.Fatalf("unexpected bytes written (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want, b.Bytes()); diff != "" {
+		t.Fatalf("unexpected encoding (-want +got):\n%s", diff)
+	}
+
+	// Errors from the io.Writer are returned along with the bytes written
+	// before the error.
+	w := &limitWriter{n: 4}
+	n, err = m.WriteTo(w)
+	if err == nil {
+		t.Fatal("expected write error, but none occurred")
+	}
+	if diff := cmp.Diff(int64(w.written), n); diff != "" {
+		t.Fatalf("unexpected bytes written before error (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapReadFrom(t *testing.T) {
+	want := testMap()
+
+	var b bytes.Buffer
+	wn, err := want.WriteTo(&b)
+	if err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+	enc := slices.Clone(b.Bytes())
+
+	// Data after the encoded Map must not be consumed.
+	b.WriteString("trailing")
+
+	got := ordered.NewMap[string, int](stdcmp.Compare)
+	got.Set("notfound", 0)
+
+	rn, err := got.ReadFrom(&b)
+	if err != nil {
+		t.Fatalf("failed to read: %v", err)
+	}
+
+	if diff := cmp.Diff(wn, rn); diff != "" {
+		t.Fatalf("unexpected bytes read (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want.Range(), got.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("trailing", b.String()); diff != "" {
+		t.Fatalf("unexpected remaining input (-want +got):\n%s", diff)
+	}
+
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{name: "empty", b: nil},
+		{name: "bad count", b: []byte{0xff}},
+		{name: "short", b: enc[:len(enc)-1]},
+		{name: "huge element", b: []byte{0x01, 0xff, 0xff, 0xff, 0xff, 0x0f}},
+		{name: "bad gob", b: []byte{0x01, 0x01, 0xff, 0x01, 0xff}},
+	}
+
+	// Truncated input can be detected by callers.
+	if _, err := ordered.NewMap[string, int](stdcmp.Compare).ReadFrom(bytes.NewReader(enc[:len(enc)-1])); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected unexpected EOF error, but got: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testMap()
+			if _, err := m.ReadFrom(bytes.NewReader(tt.b)); err == nil {
+				t.Fatal("expected malformed input error, but none occurred")
+			}
+
+			// The Map is not modified when an error occurs.
+			if diff := cmp.Diff(testMap().Range(), m.Range()); diff != "" {
+				t.Fatalf("unexpected pairs after error (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	var m ordered.Map[string, int]
+	if _, err := m.ReadFrom(bytes.NewReader(enc)); err == nil {
+		t.Fatal("expected zero Map error, but none occurred")
+	}
+}
+
+// A limitWriter is an io.Writer which returns an error after n bytes are
+// written.
+type limitWriter struct {
+	n, written int
+}
+
+func (w *limitWriter) Write(b []byte) (int, error) {
+	if w.written+len(b) > w.n {
+		nn := w.n - w.written
+		w.written = w.n
+		return nn, errors.New("write limit exceeded")
+	}
+
+	w.written += len(b)
+	return len(b), nil
+}
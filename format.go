@@ -0,0 +1,117 @@
+package ordered
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// String implements fmt.Stringer, producing the Map's KeyValue pairs in sorted
+// order in the format:
+//
+//	ordered.Map[k0:v0 k1:v1 ...]
+//
+// String is intended for diagnostics and does not panic for a Map which was
+// not constructed using NewMap.
+func (m *Map[K, V]) String() string {
+	if m == nil || m.cmp == nil {
+		return "ordered.Map(nil)"
+	}
+	m.check(ro)
+
+	var b strings.Builder
+	m.writePairs(&b, "%v", ":", " ")
+	return b.String()
+}
+
+// Format implements fmt.Formatter. The %v and %s verbs produce the same output
+// as String, and the %+v verb produces the Map's KeyValue pairs in sorted order
+// in the format:
+//
+//	ordered.Map[k0: v0, k1: v1, ...]
+//
+// For the %v, %+v, and %s verbs, any flags, width, and precision are applied
+// to each key and value. The %q verb produces the output of String as a quoted
+// string. Other verbs produce fmt's default formatting of the Map's fields.
+//
+// Like String, Format does not panic for a Map which was not constructed
+// using NewMap.
+func (m *Map[K, V]) Format(f fmt.State, verb rune) {
+	if m == nil || m.cmp == nil {
+		io.WriteString(f, "ordered.Map(nil)")
+		return
+	}
+	m.check(ro)
+
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			m.writePairs(f, fmt.FormatString(f, verb), ": ", ", ")
+			return
+		}
+
+		m.writePairs(f, fmt.FormatString(f, verb), ":", " ")
+	case 's':
+		// Format keys and values as %v so that non-string elements are not
+		// reported as bad verbs, matching String.
+		m.writePairs(f, fmt.FormatString(f, 'v'), ":", " ")
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), m.String())
+	default:
+		// A Map value has no methods, so this cannot recurse.
+		fmt.Fprintf(f, fmt.FormatString(f, verb), *m)
+	}
+}
+
+// writePairs writes the Map's KeyValue pairs in sorted order to w, formatting
+// each key and value using elem and separating them with kvSep, and separating
+// each pair with sep.
+func (m *Map[K, V]) writePairs(w io.Writer, elem, kvSep, sep string) {
+	io.WriteString(w, "ordered.Map[")
+	for i, k := range m.keys {
+		if i > 0 {
+			io.WriteString(w, sep)
+		}
+
+		fmt.Fprintf(w, elem, k)
+		io.WriteString(w, kvSep)
+		fmt.Fprintf(w, elem, m.vals[i])
+	}
+	io.WriteString(w, "]")
+}
+
+// LogValue implements slog.LogValuer. If K's underlying type is string, the Map
+// is logged as a group with one attribute per key in sorted order. Otherwise,
+// the Map is logged as a group of {key, value} groups in sorted order, each
+// keyed by its index.
+//
+// Like String, LogValue does not panic for a Map which was not constructed
+// using NewMap.
+func (m *Map[K, V]) LogValue() slog.Value {
+	if m == nil || m.cmp == nil {
+		return slog.StringValue("ordered.Map(nil)")
+	}
+	m.check(ro)
+
+	attrs := make([]slog.Attr, 0, len(m.keys))
+	if stringKeys[K]() {
+		for i, k := range m.keys {
+			attrs = append(attrs, slog.Any(reflect.ValueOf(k).String(), m.vals[i]))
+		}
+
+		return slog.GroupValue(attrs...)
+	}
+
+	for i, k := range m.keys {
+		attrs = append(attrs, slog.Group(
+			strconv.Itoa(i),
+			slog.Any("key", k),
+			slog.Any("value", m.vals[i]),
+		))
+	}
+
+	return slog.GroupValue(attrs...)
+}
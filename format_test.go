@@ -0,0 +1,182 @@
+package ordered_test
+
+import (
+	stdcmp "cmp"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mdlayher/ordered"
+)
+
+func TestMapString(t *testing.T) {
+	var (
+		zero ordered.Map[string, int]
+		null *ordered.Map[string, int]
+	)
+
+	tests := []struct {
+		name string
+		m    *ordered.Map[string, int]
+		want string
+	}{
+		{
+			name: "nil",
+			m:    null,
+			want: "ordered.Map(nil)",
+		},
+		{
+			name: "zero",
+			m:    &zero,
+			want: "ordered.Map(nil)",
+		},
+		{
+			name: "empty",
+			m:    ordered.NewMap[string, int](stdcmp.Compare),
+			want: "ordered.Map[]",
+		},
+		{
+			name: "OK",
+			m:    testMap(),
+			want: "ordered.Map[bar:2 baz:3 foo:1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.m.String()); diff != "" {
+				t.Fatalf("unexpected string (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMapFormat(t *testing.T) {
+	var zero ordered.Map[string, int]
+
+	floats := ordered.NewMap[string, float64](stdcmp.Compare)
+	floats.Set("pi", 3.14159)
+	floats.Set("e", 2.71828)
+
+	tests := []struct {
+		name   string
+		format string
+		v      any
+		want   string
+	}{
+		{
+			name:   "zero",
+			format: "%+v",
+			v:      &zero,
+			want:   "ordered.Map(nil)",
+		},
+		{
+			name:   "v",
+			format: "%v",
+			v:      testMap(),
+			want:   "ordered.Map[bar:2 baz:3 foo:1]",
+		},
+		{
+			name:   "plus v",
+			format: "%+v",
+			v:      testMap(),
+			want:   "ordered.Map[bar: 2, baz: 3, foo: 1]",
+		},
+		{
+			name:   "s",
+			format: "%s",
+			v:      testMap(),
+			want:   "ordered.Map[bar:2 baz:3 foo:1]",
+		},
+		{
+			name:   "q",
+			format: "%q",
+			v:      testMap(),
+			want:   `"ordered.Map[bar:2 baz:3 foo:1]"`,
+		},
+		{
+			name:   "width",
+			format: "%4v",
+			v:      testMap(),
+			want:   "ordered.Map[ bar:   2  baz:   3  foo:   1]",
+		},
+		{
+			name:   "precision",
+			format: "%.2v",
+			v:      floats,
+			want:   "ordered.Map[e:2.7 pi:3.1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, fmt.Sprintf(tt.format, tt.v)); diff != "" {
+				t.Fatalf("unexpected output (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// Other verbs fall back to formatting the Map's fields rather than
+	// producing pairs.
+	if s := fmt.Sprintf("%d", testMap()); strings.HasPrefix(s, "ordered.Map[") {
+		t.Fatalf("unexpected output for unsupported verb: %q", s)
+	}
+}
+
+func TestMapLogValue(t *testing.T) {
+	var zero ordered.Map[string, int]
+
+	ints := ordered.NewMap[int, string](stdcmp.Compare)
+	ints.Set(2, "bar")
+	ints.Set(1, "foo")
+
+	tests := []struct {
+		name string
+		v    slog.LogValuer
+		want string
+	}{
+		{
+			name: "zero",
+			v:    &zero,
+			want: "m=ordered.Map(nil)",
+		},
+		{
+			name: "empty",
+			v:    ordered.NewMap[string, int](stdcmp.Compare),
+			want: "",
+		},
+		{
+			name: "string keys",
+			v:    testMap(),
+			want: "m.bar=2 m.baz=3 m.foo=1",
+		},
+		{
+			name: "int keys",
+			v:    ints,
+			want: "m.0.key=1 m.0.value=foo m.1.key=2 m.1.value=bar",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			log := slog.New(slog.NewTextHandler(&b, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					// Only log the Map's attributes.
+					if len(groups) == 0 && a.Key != "m" {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}))
+
+			log.Info("", slog.Any("m", tt.v))
+
+			if diff := cmp.Diff(tt.want, strings.TrimSpace(b.String())); diff != "" {
+				t.Fatalf("unexpected log output (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,347 @@
+package ordered
+
+// Equal reports whether two Maps contain the same keys in the same order with
+// equal values. The comparison functions of the Maps are not compared. Values
+// are compared using ==.
+func Equal[K, V comparable](m1, m2 *Map[K, V]) bool {
+	return m1.EqualFunc(m2, func(a, b V) bool { return a == b })
+}
+
+// EqualFunc is like Equal, but compares values using eq. Keys are still
+// compared using ==. Writes to either Map from eq panic.
+func (m *Map[K, V]) EqualFunc(other *Map[K, V], eq func(a, b V) bool) bool {
+	m.check(ro)
+	other.check(ro)
+	defer m.endIter(m.beginIter())
+	defer other.endIter(other.beginIter())
+
+	if len(m.keys) != len(other.keys) {
+		return false
+	}
+
+	for i, k := range m.keys {
+		if k != other.keys[i] || !eq(m.vals[i], other.vals[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CompareTo compares the KeyValue pairs of m and other in sorted order,
+// returning -1 if m is less than other, 0 if they are equal, or +1 if m is
+// greater than other. Pairs are compared by key using the comparison function
+// from m, and then by value using cmpVal. If m is a prefix of other, m is less
+// than other, and vice versa.
+//
+// other must be ordered by a comparison function equivalent to the one used by
+// m, or the result is undefined. Writes to either Map from cmpVal panic.
+func (m *Map[K, V]) CompareTo(other *Map[K, V], cmpVal func(a, b V) int) int {
+	m.check(ro)
+	other.check(ro)
+	defer m.endIter(m.beginIter())
+	defer other.endIter(other.beginIter())
+
+	for i := 0; i < min(len(m.keys), len(other.keys)); i++ {
+		if c := m.cmp(m.keys[i], other.keys[i]); c != 0 {
+			return sign(c)
+		}
+		if c := cmpVal(m.vals[i], other.vals[i]); c != 0 {
+			return sign(c)
+		}
+	}
+
+	return sign(len(m.keys) - len(other.keys))
+}
+
+// sign returns -1, 0, or +1 according to the sign of c.
+func sign(c int) int {
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Filter produces a new Map which uses the same comparison function as m and
+// contains only the KeyValue pairs for which keep returns true. Writes to m from
+// keep panic.
+func (m *Map[K, V]) Filter(keep func(k K, v V) bool) *Map[K, V] {
+	m.check(ro)
+	defer m.endIter(m.beginIter())
+
+	out := NewMap[K, V](m.cmp)
+	for i, k := range m.keys {
+		v := m.vals[i]
+		if !keep(k, v) {
+			continue
+		}
+
+		// The keys are already sorted, so they can be appended directly.
+		out.keys = append(out.keys, k)
+		out.vals = append(out.vals, v)
+		out.m[k] = v
+	}
+
+	return out
+}
+
+// MapValues produces a new Map which uses the same comparison function and keys
+// as m, with each value produced by calling fn on the corresponding KeyValue
+// pair in m. Writes to m from fn panic.
+func MapValues[K comparable, V1, V2 any](m *Map[K, V1], fn func(k K, v V1) V2) *Map[K, V2] {
+	m.check(ro)
+	defer m.endIter(m.beginIter())
+
+	// The keys are unchanged, so they can be copied directly.
+	out := NewMapWithCapacity[K, V2](m.cmp, len(m.keys))
+	out.keys = append(out.keys, m.keys...)
+	for i, k := range m.keys {
+		v := fn(k, m.vals[i])
+		out.vals = append(out.vals, v)
+		out.m[k] = v
+	}
+
+	return out
+}
+
+// Diff compares the KeyValue pairs in from and to. added contains the pairs
+// with keys only present in to, removed contains the pairs with keys only
+// present in from, and changed contains the pairs from to with keys present in
+// both but with different values. Values are compared using ==. Each resulting
+// Map uses the comparison function from from.
+//
+// Because both Maps are already sorted, Diff compares them in linear time.
+// Therefore, to must be ordered by a comparison function equivalent to the one
+// used by from, or the results are undefined.
+func Diff[K, V comparable](from, to *Map[K, V]) (added, removed, changed *Map[K, V]) {
+	from.check(ro)
+	to.check(ro)
+
+	var (
+		cmp  = from.cmp
+		a, b = 0, 0
+	)
+
+	added, removed, changed = NewMap[K, V](cmp), NewMap[K, V](cmp), NewMap[K, V](cmp)
+	for a < len(from.keys) && b < len(to.keys) {
+		ka, kb := from.keys[a], to.keys[b]
+		switch c := cmp(ka, kb); {
+		case c < 0:
+			removed.appendKV(ka, from.vals[a])
+			a++
+		case c > 0:
+			added.appendKV(kb, to.vals[b])
+			b++
+		default:
+			if from.vals[a] != to.vals[b] {
+				changed.appendKV(kb, to.vals[b])
+			}
+			a, b = a+1, b+1
+		}
+	}
+
+	for ; a < len(from.keys); a++ {
+		removed.appendKV(from.keys[a], from.vals[a])
+	}
+	for ; b < len(to.keys); b++ {
+		added.appendKV(to.keys[b], to.vals[b])
+	}
+
+	return added, removed, changed
+}
+
+// Invert produces a new Map keyed by the values of m and ordered by cmp, with
+// each value set to the corresponding key from m. See NewMap for details on
+// cmp. If more than one key in m maps to the same value, the last such key in
+// sorted order wins.
+func Invert[K, V comparable](m *Map[K, V], cmp func(a, b V) int) *Map[V, K] {
+	m.check(ro)
+	if cmp == nil {
+		panic("ordered: Invert must use a non-nil cmp function")
+	}
+
+	out := NewMapWithCapacity[V, K](cmp, len(m.keys))
+	for i, k := range m.keys {
+		out.m[m.vals[i]] = k
+	}
+
+	out.sortKeys()
+	return out
+}
+
+// GroupBy partitions the KeyValue pairs in m into groups keyed by the result of
+// calling keyFn on each pair. The groups are ordered by groupCmp, and each group
+// is a new Map which uses the same comparison function as m. See NewMap for
+// details on groupCmp. Writes to m from keyFn or groupCmp panic.
+func GroupBy[K comparable, V any, G comparable](
+	m *Map[K, V],
+	groupCmp func(a, b G) int,
+	keyFn func(k K, v V) G,
+) *Map[G, *Map[K, V]] {
+	m.check(ro)
+	defer m.endIter(m.beginIter())
+	if groupCmp == nil {
+		panic("ordered: GroupBy must use a non-nil groupCmp function")
+	}
+
+	out := NewMap[G, *Map[K, V]](groupCmp)
+	for i, k := range m.keys {
+		v := m.vals[i]
+		g := keyFn(k, v)
+
+		group, ok := out.m[g]
+		if !ok {
+			group = NewMap[K, V](m.cmp)
+			out.m[g] = group
+		}
+
+		// The keys are visited in sorted order, so they can be appended to
+		// each group directly.
+		group.keys = append(group.keys, k)
+		group.vals = append(group.vals, v)
+		group.m[k] = v
+	}
+
+	out.sortKeys()
+	return out
+}
+
+// Reduce calls fn for each KeyValue pair in m in sorted order, accumulating the
+// result of each call into acc, beginning with init. Reduce returns the final
+// accumulated value. Writes to m from fn panic.
+func Reduce[K comparable, V, R any](m *Map[K, V], init R, fn func(acc R, k K, v V) R) R {
+	m.check(ro)
+	defer m.endIter(m.beginIter())
+
+	acc := init
+	for i, k := range m.keys {
+		acc = fn(acc, k, m.vals[i])
+	}
+
+	return acc
+}
+
+// ForEach calls fn for each KeyValue pair in m in sorted order. If fn returns
+// false, ForEach stops iteration. Writes to m from fn panic.
+func (m *Map[K, V]) ForEach(fn func(k K, v V) bool) {
+	m.check(ro)
+	defer m.endIter(m.beginIter())
+
+	for i, k := range m.keys {
+		if !fn(k, m.vals[i]) {
+			return
+		}
+	}
+}
+
+// DeleteFunc deletes each KeyValue pair in m for which del returns true. Writes
+// to m from del panic.
+func (m *Map[K, V]) DeleteFunc(del func(k K, v V) bool) {
+	m.check(rw)
+	defer m.endIter(m.beginIter())
+
+	// Deletion preserves the relative order of the remaining keys, so the keys
+	// and values can be compacted together in a single pass.
+	var n int
+	for i, k := range m.keys {
+		v := m.vals[i]
+		if del(k, v) {
+			delete(m.m, k)
+			continue
+		}
+
+		m.keys[n], m.vals[n] = k, v
+		n++
+	}
+
+	// Zero the tail so deleted keys and values can be garbage collected.
+	clear(m.keys[n:])
+	clear(m.vals[n:])
+	m.keys, m.vals = m.keys[:n], m.vals[:n]
+}
+
+// RetainFunc is the inverse of DeleteFunc: it deletes each KeyValue pair in m
+// for which keep returns false. Writes to m from keep panic.
+func (m *Map[K, V]) RetainFunc(keep func(k K, v V) bool) {
+	m.DeleteFunc(func(k K, v V) bool { return !keep(k, v) })
+}
+
+// Count returns the number of KeyValue pairs in m for which pred returns true.
+// Writes to m from pred panic.
+func (m *Map[K, V]) Count(pred func(k K, v V) bool) int {
+	m.check(ro)
+	defer m.endIter(m.beginIter())
+
+	var n int
+	for i, k := range m.keys {
+		if pred(k, m.vals[i]) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Any reports whether pred returns true for any KeyValue pair in m. Any stops
+// iteration at the first pair for which pred returns true. Writes to m from
+// pred panic.
+func (m *Map[K, V]) Any(pred func(k K, v V) bool) bool {
+	m.check(ro)
+	defer m.endIter(m.beginIter())
+
+	for i, k := range m.keys {
+		if pred(k, m.vals[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Every reports whether pred returns true for every KeyValue pair in m, or if m
+// is empty. Every stops iteration at the first pair for which pred returns
+// false. Writes to m from pred panic.
+func (m *Map[K, V]) Every(pred func(k K, v V) bool) bool {
+	return !m.Any(func(k K, v V) bool { return !pred(k, v) })
+}
+
+// Find returns the first KeyValue pair in m, in sorted order, for which pred
+// returns true. If no such pair exists, Find returns false. Writes to m from
+// pred panic.
+func (m *Map[K, V]) Find(pred func(k K, v V) bool) (KeyValue[K, V], bool) {
+	m.check(ro)
+	defer m.endIter(m.beginIter())
+
+	for i, k := range m.keys {
+		if pred(k, m.vals[i]) {
+			return m.kv(i), true
+		}
+	}
+
+	return KeyValue[K, V]{}, false
+}
+
+// ContainsValue reports whether any key in m maps to the value v. Values are
+// compared using == in sorted key order, stopping at the first match.
+func ContainsValue[K, V comparable](m *Map[K, V], v V) bool {
+	return m.ContainsValueFunc(v, func(a, b V) bool { return a == b })
+}
+
+// ContainsValueFunc is like ContainsValue, but compares values using eq. Writes
+// to m from eq panic.
+func (m *Map[K, V]) ContainsValueFunc(v V, eq func(a, b V) bool) bool {
+	m.check(ro)
+	defer m.endIter(m.beginIter())
+
+	for _, mv := range m.vals {
+		if eq(mv, v) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,464 @@
+package ordered_test
+
+import (
+	stdcmp "cmp"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mdlayher/ordered"
+)
+
+func TestEqual(t *testing.T) {
+	changed := testMap()
+	changed.Set("foo", 10)
+
+	extra := testMap()
+	extra.Set("qux", 4)
+
+	reversed := ordered.NewMap[string, int](ordered.Reverse(stdcmp.Compare[string]))
+	reversed.SetMany(testMap().Range()...)
+
+	tests := []struct {
+		name string
+		m    *ordered.Map[string, int]
+		ok   bool
+	}{
+		{name: "equal", m: testMap(), ok: true},
+		{name: "changed value", m: changed},
+		{name: "extra key", m: extra},
+		{name: "different order", m: reversed},
+		{name: "empty", m: ordered.NewMap[string, int](stdcmp.Compare)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ordered.Equal(testMap(), tt.m); got != tt.ok {
+				t.Fatalf("unexpected equality: want %v, got %v", tt.ok, got)
+			}
+		})
+	}
+}
+
+func TestMapEqualFunc(t *testing.T) {
+	newMap := func(kvs ...ordered.KeyValue[string, []int]) *ordered.Map[string, []int] {
+		m := ordered.NewMap[string, []int](stdcmp.Compare)
+		m.SetMany(kvs...)
+		return m
+	}
+
+	var (
+		a = newMap(
+			ordered.KeyValue[string, []int]{Key: "foo", Value: []int{1, 2}},
+			ordered.KeyValue[string, []int]{Key: "bar", Value: []int{3}},
+		)
+		b = newMap(
+			ordered.KeyValue[string, []int]{Key: "foo", Value: []int{1, 2}},
+			ordered.KeyValue[string, []int]{Key: "bar", Value: []int{3}},
+		)
+		c = newMap(
+			ordered.KeyValue[string, []int]{Key: "foo", Value: []int{1, 2}},
+			ordered.KeyValue[string, []int]{Key: "baz", Value: []int{3}},
+		)
+		d = newMap(
+			ordered.KeyValue[string, []int]{Key: "foo", Value: []int{1}},
+			ordered.KeyValue[string, []int]{Key: "bar", Value: []int{3}},
+		)
+	)
+
+	if !a.EqualFunc(b, slices.Equal[[]int]) {
+		t.Fatal("a and b are not equal")
+	}
+	if a.EqualFunc(c, slices.Equal[[]int]) {
+		t.Fatal("a and c are equal despite different keys")
+	}
+	if a.EqualFunc(d, slices.Equal[[]int]) {
+		t.Fatal("a and d are equal despite different values")
+	}
+}
+
+func TestMapCompareTo(t *testing.T) {
+	with := func(fn func(m *ordered.Map[string, int])) *ordered.Map[string, int] {
+		m := testMap()
+		fn(m)
+		return m
+	}
+
+	tests := []struct {
+		name string
+		m    *ordered.Map[string, int]
+		want int
+	}{
+		{
+			name: "equal",
+			m:    testMap(),
+			want: 0,
+		},
+		{
+			name: "smaller key",
+			m:    with(func(m *ordered.Map[string, int]) { m.Set("aaa", 100) }),
+			want: -1,
+		},
+		{
+			name: "larger key",
+			m:    with(func(m *ordered.Map[string, int]) { m.Delete("bar") }),
+			want: 1,
+		},
+		{
+			name: "smaller value",
+			m:    with(func(m *ordered.Map[string, int]) { m.Set("baz", 0) }),
+			want: -1,
+		},
+		{
+			name: "larger value",
+			m:    with(func(m *ordered.Map[string, int]) { m.Set("foo", 100) }),
+			want: 1,
+		},
+		{
+			name: "prefix",
+			m:    with(func(m *ordered.Map[string, int]) { m.Delete("foo") }),
+			want: -1,
+		},
+		{
+			name: "longer",
+			m:    with(func(m *ordered.Map[string, int]) { m.Set("qux", 0) }),
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.m.CompareTo(testMap(), func(a, b int) int { return (a - b) * 10 })
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected comparison (-want +got):\n%s", diff)
+			}
+
+			// The comparison is antisymmetric.
+			if diff := cmp.Diff(-tt.want, testMap().CompareTo(tt.m, stdcmp.Compare[int])); diff != "" {
+				t.Fatalf("unexpected reversed comparison (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMapFilter(t *testing.T) {
+	m := testMap()
+	got := m.Filter(func(k string, v int) bool { return k != "bar" && v < 3 })
+
+	want := []ordered.KeyValue[string, int]{{Key: "foo", Value: 1}}
+	if diff := cmp.Diff(want, got.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+
+	// The output is independent of the input.
+	got.Set("aaa", 0)
+	if diff := cmp.Diff(testMap().Range(), m.Range()); diff != "" {
+		t.Fatalf("unexpected input pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapValuesFunc(t *testing.T) {
+	got := ordered.MapValues(testMap(), func(k string, v int) string {
+		return fmt.Sprintf("%s=%d", k, v)
+	})
+
+	want := []ordered.KeyValue[string, string]{
+		{Key: "bar", Value: "bar=2"},
+		{Key: "baz", Value: "baz=3"},
+		{Key: "foo", Value: "foo=1"},
+	}
+
+	if diff := cmp.Diff(want, got.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	from := testMap()
+	from.Set("aaa", 0)
+
+	to := testMap()
+	to.Delete("baz")
+	to.Set("foo", 10)
+	to.Set("qux", 4)
+
+	added, removed, changed := ordered.Diff(from, to)
+
+	for _, tt := range []struct {
+		name string
+		want []ordered.KeyValue[string, int]
+		got  *ordered.Map[string, int]
+	}{
+		{
+			name: "added",
+			want: []ordered.KeyValue[string, int]{{Key: "qux", Value: 4}},
+			got:  added,
+		},
+		{
+			name: "removed",
+			want: []ordered.KeyValue[string, int]{
+				{Key: "aaa", Value: 0},
+				{Key: "baz", Value: 3},
+			},
+			got: removed,
+		},
+		{
+			name: "changed",
+			want: []ordered.KeyValue[string, int]{{Key: "foo", Value: 10}},
+			got:  changed,
+		},
+	} {
+		if diff := cmp.Diff(tt.want, tt.got.Range()); diff != "" {
+			t.Fatalf("unexpected %s pairs (-want +got):\n%s", tt.name, diff)
+		}
+	}
+
+	// Identical Maps produce no differences.
+	added, removed, changed = ordered.Diff(testMap(), testMap())
+	if n := added.Len() + removed.Len() + changed.Len(); n != 0 {
+		t.Fatalf("unexpected differences for identical maps: %d", n)
+	}
+}
+
+func TestInvert(t *testing.T) {
+	m := testMap()
+	m.Set("qux", 3)
+
+	// "qux" sorts after "baz", so it wins for the shared value 3.
+	want := []ordered.KeyValue[int, string]{
+		{Key: 1, Value: "foo"},
+		{Key: 2, Value: "bar"},
+		{Key: 3, Value: "qux"},
+	}
+
+	if diff := cmp.Diff(want, ordered.Invert(m, stdcmp.Compare[int]).Range()); diff != "" {
+		t.Fatalf("unexpected inverted pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	m := testMap()
+	m.Set("qux", 4)
+
+	// Group each key by whether its value is odd or even.
+	groups := ordered.GroupBy(m, stdcmp.Compare[string], func(_ string, v int) string {
+		if v%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	})
+
+	want := map[string][]ordered.KeyValue[string, int]{
+		"even": {{Key: "bar", Value: 2}, {Key: "qux", Value: 4}},
+		"odd":  {{Key: "baz", Value: 3}, {Key: "foo", Value: 1}},
+	}
+
+	got := make(map[string][]ordered.KeyValue[string, int])
+	for _, kv := range groups.Range() {
+		got[kv.Key] = kv.Value.Range()
+	}
+
+	if diff := cmp.Diff([]string{"even", "odd"}, groups.Keys()); diff != "" {
+		t.Fatalf("unexpected group keys (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected groups (-want +got):\n%s", diff)
+	}
+
+	// Each group remains usable with the original comparison function.
+	g := groups.Get("even")
+	g.Set("baa", 0)
+	if diff := cmp.Diff([]string{"baa", "bar", "qux"}, g.Keys()); diff != "" {
+		t.Fatalf("unexpected group keys after set (-want +got):\n%s", diff)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	// String concatenation is not commutative, so order matters.
+	got := ordered.Reduce(testMap(), "", func(acc string, k string, v int) string {
+		return fmt.Sprintf("%s%s%d", acc, k, v)
+	})
+
+	if diff := cmp.Diff("bar2baz3foo1", got); diff != "" {
+		t.Fatalf("unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapForEach(t *testing.T) {
+	m := testMap()
+
+	// Reads okay during iteration.
+	mi := m.Iter()
+	defer mi.Close()
+
+	var got []string
+	m.ForEach(func(k string, _ int) bool {
+		got = append(got, k)
+		return k != "baz"
+	})
+
+	if diff := cmp.Diff([]string{"bar", "baz"}, got); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		m.ForEach(func(string, int) bool { return true })
+	})
+	if allocs != 0 {
+		t.Fatalf("unexpected allocations: %v", allocs)
+	}
+}
+
+func TestMapCallbackWritePanics(t *testing.T) {
+	m := testMap()
+
+	if !panics(t, func() {
+		m.ForEach(func(k string, _ int) bool {
+			m.Delete(k)
+			return true
+		})
+	}) {
+		t.Fatal("expected write panic during ForEach, but got none")
+	}
+
+	// Writes are permitted after the callback panics.
+	m.Set("qux", 4)
+	if diff := cmp.Diff(4, m.Len()); diff != "" {
+		t.Fatalf("unexpected length (-want +got):\n%s", diff)
+	}
+
+	m = testMap()
+	if !panics(t, func() {
+		m.DeleteFunc(func(k string, _ int) bool {
+			m.Set("aaa", 9)
+			return k == "baz"
+		})
+	}) {
+		t.Fatal("expected write panic during DeleteFunc, but got none")
+	}
+}
+
+func TestMapDeleteFunc(t *testing.T) {
+	m := testMap()
+	m.DeleteFunc(func(k string, v int) bool { return k == "foo" || v == 2 })
+
+	want := []ordered.KeyValue[string, int]{{Key: "baz", Value: 3}}
+	if diff := cmp.Diff(want, m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(0, m.Get("foo")); diff != "" {
+		t.Fatalf("unexpected foo value (-want +got):\n%s", diff)
+	}
+
+	_ = m.Iter()
+	if !panics(t, func() { m.DeleteFunc(func(string, int) bool { return true }) }) {
+		t.Fatal("expected write panic during iteration, but got none")
+	}
+}
+
+func TestMapRetainFunc(t *testing.T) {
+	m := testMap()
+	m.RetainFunc(func(k string, v int) bool { return k == "foo" || v == 2 })
+
+	want := []ordered.KeyValue[string, int]{
+		{Key: "bar", Value: 2},
+		{Key: "foo", Value: 1},
+	}
+
+	if diff := cmp.Diff(want, m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapCount(t *testing.T) {
+	m := testMap()
+
+	if diff := cmp.Diff(2, m.Count(func(_ string, v int) bool { return v > 1 })); diff != "" {
+		t.Fatalf("unexpected count (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(0, m.Count(func(string, int) bool { return false })); diff != "" {
+		t.Fatalf("unexpected empty count (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapAnyEvery(t *testing.T) {
+	var (
+		m     = testMap()
+		empty = ordered.NewMap[string, int](stdcmp.Compare)
+
+		positive = func(_ string, v int) bool { return v > 0 }
+		two      = func(_ string, v int) bool { return v == 2 }
+		never    = func(string, int) bool { return false }
+	)
+
+	tests := []struct {
+		name     string
+		m        *ordered.Map[string, int]
+		pred     func(string, int) bool
+		any, all bool
+	}{
+		{name: "positive", m: m, pred: positive, any: true, all: true},
+		{name: "two", m: m, pred: two, any: true},
+		{name: "never", m: m, pred: never},
+		{name: "empty", m: empty, pred: never, all: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.any, tt.m.Any(tt.pred)); diff != "" {
+				t.Fatalf("unexpected any (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.all, tt.m.Every(tt.pred)); diff != "" {
+				t.Fatalf("unexpected every (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// Both stop at the first decisive pair.
+	var calls int
+	m.Any(func(string, int) bool { calls++; return true })
+	m.Every(func(string, int) bool { calls++; return false })
+	if diff := cmp.Diff(2, calls); diff != "" {
+		t.Fatalf("unexpected number of calls (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapFind(t *testing.T) {
+	m := testMap()
+
+	kv, ok := m.Find(func(k string, _ int) bool { return k > "b" })
+	if !ok {
+		t.Fatal("no pair found")
+	}
+
+	if diff := cmp.Diff(ordered.KeyValue[string, int]{Key: "bar", Value: 2}, kv); diff != "" {
+		t.Fatalf("unexpected pair (-want +got):\n%s", diff)
+	}
+
+	if _, ok := m.Find(func(string, int) bool { return false }); ok {
+		t.Fatal("pair found for false predicate")
+	}
+}
+
+func TestContainsValue(t *testing.T) {
+	m := testMap()
+
+	if !ordered.ContainsValue(m, 3) {
+		t.Fatal("map does not contain value 3")
+	}
+	if ordered.ContainsValue(m, 4) {
+		t.Fatal("map contains value 4")
+	}
+
+	// Values are visited in key order with the map's value first.
+	var got []int
+	m.ContainsValueFunc(1, func(a, b int) bool {
+		got = append(got, a)
+		return a == b
+	})
+
+	if diff := cmp.Diff([]int{2, 3, 1}, got); diff != "" {
+		t.Fatalf("unexpected compared values (-want +got):\n%s", diff)
+	}
+}
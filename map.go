@@ -1,6 +1,8 @@
 package ordered
 
 import (
+	"fmt"
+	"maps"
 	"slices"
 	"sync/atomic"
 )
@@ -24,22 +26,39 @@ const (
 //
 // Maps are not safe for concurrent use.
 type Map[K comparable, V any] struct {
-	// Atomic: whether or not a MapIterator is live for this Map.
-	iter int32
+	// Atomic: whether or not a MapIterator is live for this Map, and the number
+	// of calls to ResetIterators, used to detect MapIterators which were opened
+	// before the most recent call.
+	iter, gen int32
 
 	// A sorted list of keys stored in the map and the function to compare those
-	// keys.
+	// keys, and the values for each of those keys in the same order. Storing
+	// the values alongside the keys allows iteration to read them contiguously.
 	keys []K
+	vals []V
 	cmp  func(a, b K) int
 
-	// The actual underlying map storage.
+	// The underlying map storage, used for lookups by key. Each value must also
+	// be kept in sync with vals.
 	m map[K]V
+
+	// Whether or not the Map has been frozen by Freeze.
+	frozen bool
 }
 
-// NewMap creates a *Map[K, V] which uses the a comparison function to order the
-// keys in the map. cmp must not be nil or NewMap will panic. For types which
-// meet the [cmp.Ordered] constraint, [cmp.Compare] can be used as a comparison
-// function.
+// NewMap creates a *Map[K, V] which uses a comparison function to order the
+// keys in the map. cmp must not be nil or NewMap will panic. cmp must return a
+// negative number when a < b, a positive number when a > b, and zero when a ==
+// b. For types which meet the [cmp.Ordered] constraint, [cmp.Compare] can be
+// used as a comparison function.
+//
+// NaN keys are not supported. NaN is never equal to itself, so a NaN key cannot
+// be retrieved from or deleted from the Map's underlying Go map storage, and
+// storing NaN more than once produces duplicate keys. When cmp is
+// [cmp.Compare], NaN keys still sort deterministically before all other keys.
+//
+// Building with -tags ordereddebug enables checks which panic when cmp does not
+// keep the Map's keys sorted, at the cost of O(n) work after each insertion.
 func NewMap[K comparable, V any](cmp func(a, b K) int) *Map[K, V] {
 	if cmp == nil {
 		panic("ordered: NewMap must use a non-nil cmp function")
@@ -51,6 +70,103 @@ func NewMap[K comparable, V any](cmp func(a, b K) int) *Map[K, V] {
 	}
 }
 
+// NewMapWithCapacity is like NewMap, but preallocates storage for capacity
+// elements. capacity must not be negative or NewMapWithCapacity will panic.
+func NewMapWithCapacity[K comparable, V any](cmp func(a, b K) int, capacity int) *Map[K, V] {
+	if cmp == nil {
+		panic("ordered: NewMapWithCapacity must use a non-nil cmp function")
+	}
+	if capacity < 0 {
+		panic("ordered: NewMapWithCapacity must use a non-negative capacity")
+	}
+
+	return &Map[K, V]{
+		keys: make([]K, 0, capacity),
+		vals: make([]V, 0, capacity),
+		m:    make(map[K]V, capacity),
+		cmp:  cmp,
+	}
+}
+
+// An Option configures a Map created by NewMapOpts.
+type Option[K comparable, V any] func(o *options[K])
+
+// options are the options applied to a Map by NewMapOpts.
+type options[K comparable] struct {
+	cmp      func(a, b K) int
+	capacity int
+}
+
+// WithCompareFunc returns an Option which uses a comparison function to order
+// the keys in a Map. See NewMap for details.
+func WithCompareFunc[K comparable, V any](cmp func(a, b K) int) Option[K, V] {
+	return func(o *options[K]) { o.cmp = cmp }
+}
+
+// WithCapacity returns an Option which preallocates storage for capacity
+// elements in a Map. See NewMapWithCapacity for details.
+func WithCapacity[K comparable, V any](capacity int) Option[K, V] {
+	return func(o *options[K]) { o.capacity = capacity }
+}
+
+// NewMapOpts creates a *Map[K, V] configured by opts. The options must include
+// a non-nil comparison function using WithCompareFunc or NewMapOpts will panic.
+func NewMapOpts[K comparable, V any](opts ...Option[K, V]) *Map[K, V] {
+	var o options[K]
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.cmp == nil {
+		panic("ordered: NewMapOpts must use a non-nil cmp function")
+	}
+	if o.capacity < 0 {
+		panic("ordered: NewMapOpts must use a non-negative capacity")
+	}
+
+	return NewMapWithCapacity[K, V](o.cmp, o.capacity)
+}
+
+// NewMapFromGoMap creates a *Map[K, V] which contains a copy of the elements of
+// m, ordered by cmp. See NewMap for details on cmp. NewMapFromGoMap sorts the
+// keys once after all elements are copied.
+func NewMapFromGoMap[K comparable, V any](m map[K]V, cmp func(a, b K) int) *Map[K, V] {
+	if cmp == nil {
+		panic("ordered: NewMapFromGoMap must use a non-nil cmp function")
+	}
+
+	out := &Map[K, V]{
+		keys: make([]K, 0, len(m)),
+		vals: make([]V, 0, len(m)),
+		m:    maps.Clone(m),
+		cmp:  cmp,
+	}
+	if out.m == nil {
+		out.m = make(map[K]V)
+	}
+
+	out.sortKeys()
+	return out
+}
+
+// NewMapFromPairs creates a *Map[K, V] which contains the KeyValue pairs in
+// pairs, ordered by cmp. See NewMap for details on cmp. If a key K appears
+// more than once, the last pair wins. NewMapFromPairs sorts the keys once after
+// all pairs are stored.
+func NewMapFromPairs[K comparable, V any](cmp func(a, b K) int, pairs ...KeyValue[K, V]) *Map[K, V] {
+	if cmp == nil {
+		panic("ordered: NewMapFromPairs must use a non-nil cmp function")
+	}
+
+	m := NewMapWithCapacity[K, V](cmp, len(pairs))
+	for _, kv := range pairs {
+		m.m[kv.Key] = kv.Value
+	}
+
+	m.sortKeys()
+	return m
+}
+
 // Get gets the value V for a given key K, returning the zero value of V if K is
 // not found.
 func (m *Map[K, V]) Get(k K) V {
@@ -66,55 +182,511 @@ func (m *Map[K, V]) TryGet(k K) (V, bool) {
 	return v, ok
 }
 
+// GetOrDefault gets the value V for a given key K, returning def if K is not
+// found.
+func (m *Map[K, V]) GetOrDefault(k K, def V) V {
+	m.check(ro)
+	if v, ok := m.m[k]; ok {
+		return v
+	}
+
+	return def
+}
+
+// Has reports whether a given key K is present in the Map.
+func (m *Map[K, V]) Has(k K) bool {
+	m.check(ro)
+	_, ok := m.m[k]
+	return ok
+}
+
 // Len returns the number of elements in the Map.
 func (m *Map[K, V]) Len() int {
 	m.check(ro)
 	return len(m.keys)
 }
 
+// Cap returns the number of elements the Map's sorted key storage can hold
+// before it must be reallocated.
+func (m *Map[K, V]) Cap() int {
+	m.check(ro)
+	return cap(m.keys)
+}
+
+// Comparator returns the comparison function used to order the keys in the
+// Map.
+func (m *Map[K, V]) Comparator() func(a, b K) int {
+	m.check(ro)
+	return m.cmp
+}
+
 // Set inserts or updates the value V for a given key K.
 func (m *Map[K, V]) Set(k K, v V) {
 	m.check(rw)
+	m.store(k, v)
+}
 
-	if _, ok := m.m[k]; !ok {
-		// Always sort when a new key is inserted.
-		m.keys = append(m.keys, k)
-		slices.SortFunc(m.keys, m.cmp)
+// Push inserts or updates the value V for a given key K. Push is an alias for
+// Set, intended for use when a Map is used as a priority queue alongside
+// PopMin and PopMax.
+//
+// Because the keys are always kept sorted, inserting a new key with Push is
+// O(n), while PopMin and PopMax are O(1). A binary heap such as
+// [container/heap] offers O(log n) insertion and removal, so prefer it for
+// large queues which are frequently inserted into.
+func (m *Map[K, V]) Push(k K, v V) {
+	m.Set(k, v)
+}
+
+// GetOrSet gets the value V for a given key K and returns true if K is found.
+// Otherwise, GetOrSet inserts v for K and returns v and false.
+func (m *Map[K, V]) GetOrSet(k K, v V) (V, bool) {
+	m.check(rw)
+
+	if old, ok := m.m[k]; ok {
+		return old, true
+	}
+
+	m.store(k, v)
+	return v, false
+}
+
+// SetIfAbsent inserts the value V for a given key K and returns true if K is
+// not found. Otherwise, SetIfAbsent leaves the existing value unmodified and
+// returns false.
+func (m *Map[K, V]) SetIfAbsent(k K, v V) bool {
+	m.check(rw)
+
+	if _, ok := m.m[k]; ok {
+		return false
+	}
+
+	m.store(k, v)
+	return true
+}
+
+// Update calls fn with the current value V for a given key K and whether K was
+// found, and then stores the value returned by fn for K.
+func (m *Map[K, V]) Update(k K, fn func(old V, ok bool) V) {
+	m.check(rw)
+
+	old, ok := m.m[k]
+	m.store(k, fn(old, ok))
+}
+
+// store inserts or updates the value V for a given key K.
+func (m *Map[K, V]) store(k K, v V) {
+	// The underlying map decides whether k is present, and the order index is
+	// only searched to find the position of its value.
+	_, ok := m.m[k]
+	i, _ := m.search(k)
+	if ok {
+		m.vals[i] = v
+	} else {
+		// The keys are always sorted, so insert new keys at their sorted
+		// position rather than sorting again.
+		m.keys = slices.Insert(m.keys, i, k)
+		m.vals = slices.Insert(m.vals, i, v)
 	}
 
 	m.m[k] = v
+
+	if debug {
+		m.verify()
+	}
+}
+
+// SetMany inserts or updates the values for each KeyValue pair. If a key K
+// appears more than once, the last pair wins. SetMany sorts the Map's keys once
+// after all pairs are stored, making it more efficient than calling Set for
+// each pair.
+func (m *Map[K, V]) SetMany(kvs ...KeyValue[K, V]) {
+	m.check(rw)
+
+	for _, kv := range kvs {
+		m.m[kv.Key] = kv.Value
+	}
+
+	m.sortKeys()
+}
+
+// SetAll inserts or updates the values for each key K in src, with values from
+// src replacing any existing values in m. Like SetMany, SetAll updates the
+// Map's keys once after all pairs are stored.
+func (m *Map[K, V]) SetAll(src map[K]V) {
+	m.check(rw)
+
+	var added []KeyValue[K, V]
+	for k, v := range src {
+		if _, ok := m.m[k]; !ok {
+			added = append(added, KeyValue[K, V]{Key: k, Value: v})
+		} else {
+			m.update(k, v)
+		}
+
+		m.m[k] = v
+	}
+
+	m.mergePairs(added)
+}
+
+// Merge inserts or updates the values for each key K in other, with values from
+// other replacing any existing values in m.
+func (m *Map[K, V]) Merge(other *Map[K, V]) {
+	m.MergeFunc(other, func(_ K, _, b V) V { return b })
+}
+
+// MergeFunc is like Merge, but calls resolve to determine the value for each key
+// K which is present in both m and other. resolve receives the value from m as
+// a and the value from other as b, and its result is stored in m. Keys only
+// present in other are inserted directly.
+func (m *Map[K, V]) MergeFunc(other *Map[K, V], resolve func(k K, a, b V) V) {
+	m.check(rw)
+	other.check(ro)
+
+	var added []KeyValue[K, V]
+	for i, k := range other.keys {
+		b := other.vals[i]
+		if a, ok := m.m[k]; ok {
+			v := resolve(k, a, b)
+			m.update(k, v)
+			m.m[k] = v
+			continue
+		}
+
+		added = append(added, KeyValue[K, V]{Key: k, Value: b})
+		m.m[k] = b
+	}
+
+	m.mergePairs(added)
+}
+
+// update updates the value in the order index for a key K which is already
+// present in the Map. The caller must also update the underlying map storage.
+func (m *Map[K, V]) update(k K, v V) {
+	i, _ := m.search(k)
+	m.vals[i] = v
+}
+
+// appendKV inserts a KeyValue pair for a key K which must sort after all other
+// keys in the Map, avoiding a search of the order index.
+func (m *Map[K, V]) appendKV(k K, v V) {
+	m.keys = append(m.keys, k)
+	m.vals = append(m.vals, v)
+	m.m[k] = v
+}
+
+// mergePairs merges KeyValue pairs whose keys were newly added to the
+// underlying map storage into the order index.
+func (m *Map[K, V]) mergePairs(added []KeyValue[K, V]) {
+	if len(added) > 0 {
+		// The added pairs may have been ordered by another comparison function,
+		// so sort them first. The order index is already sorted, so the two can
+		// then be merged in linear time.
+		slices.SortFunc(added, func(a, b KeyValue[K, V]) int {
+			return m.cmp(a.Key, b.Key)
+		})
+
+		var (
+			n    = len(m.keys) + len(added)
+			keys = make([]K, 0, n)
+			vals = make([]V, 0, n)
+			i    int
+		)
+
+		for _, kv := range added {
+			for i < len(m.keys) && m.cmp(m.keys[i], kv.Key) <= 0 {
+				keys = append(keys, m.keys[i])
+				vals = append(vals, m.vals[i])
+				i++
+			}
+
+			keys = append(keys, kv.Key)
+			vals = append(vals, kv.Value)
+		}
+
+		m.keys = append(keys, m.keys[i:]...)
+		m.vals = append(vals, m.vals[i:]...)
+	}
+
+	if debug {
+		m.verify()
+	}
+}
+
+// sortKeys rebuilds and sorts the order index from the underlying map storage.
+func (m *Map[K, V]) sortKeys() {
+	m.keys = m.keys[:0]
+	for k := range m.m {
+		m.keys = append(m.keys, k)
+	}
+
+	slices.SortFunc(m.keys, m.cmp)
+	m.syncValues()
+
+	if debug {
+		m.verify()
+	}
+}
+
+// syncValues rebuilds the values in key order from the underlying map storage.
+func (m *Map[K, V]) syncValues() {
+	clear(m.vals)
+	m.vals = m.vals[:0]
+	for _, k := range m.keys {
+		m.vals = append(m.vals, m.m[k])
+	}
 }
 
 // Delete deletes the value for a given key K.
 func (m *Map[K, V]) Delete(k K) {
 	m.check(rw)
 
-	i := slices.Index(m.keys, k)
-	if i != -1 {
-		// Found this key, remove it from the order index.
-		m.keys = slices.Delete(m.keys, i, i+1)
+	if i, ok := m.search(k); ok {
+		m.remove(i)
 	}
+}
 
-	delete(m.m, k)
+// Pop deletes the value for a given key K and returns it and true if K is
+// found. Otherwise, Pop returns the zero value of V and false.
+func (m *Map[K, V]) Pop(k K) (V, bool) {
+	m.check(rw)
+
+	i, ok := m.search(k)
+	if !ok {
+		var v V
+		return v, false
+	}
+
+	v := m.vals[i]
+	m.remove(i)
+	return v, true
+}
+
+// DeleteRange deletes the values for all keys in the half-open interval
+// [lo, hi) and returns the number of KeyValue pairs deleted. If lo is greater
+// than or equal to hi, DeleteRange deletes nothing.
+func (m *Map[K, V]) DeleteRange(lo, hi K) int {
+	m.check(rw)
+
+	i, j := m.interval(lo, hi)
+	m.removeRange(i, j)
+	return j - i
+}
+
+// remove removes the key at index i and its value from the Map.
+func (m *Map[K, V]) remove(i int) {
+	m.removeRange(i, i+1)
+}
+
+// removeRange removes the keys in the index interval [i, j) and their values
+// from the Map.
+func (m *Map[K, V]) removeRange(i, j int) {
+	for _, k := range m.keys[i:j] {
+		delete(m.m, k)
+	}
+
+	m.keys = slices.Delete(m.keys, i, j)
+	m.vals = slices.Delete(m.vals, i, j)
+}
+
+// PopMin removes and returns the KeyValue pair with the smallest key in the
+// Map, returning false if the Map is empty.
+func (m *Map[K, V]) PopMin() (KeyValue[K, V], bool) {
+	m.check(rw)
+
+	if len(m.keys) == 0 {
+		return KeyValue[K, V]{}, false
+	}
+
+	kv := m.kv(0)
+
+	// Zero the removed key and value so they can be garbage collected.
+	var (
+		zk K
+		zv V
+	)
+	m.keys[0], m.vals[0] = zk, zv
+	m.keys, m.vals = m.keys[1:], m.vals[1:]
+	delete(m.m, kv.Key)
+
+	return kv, true
+}
+
+// PopMax removes and returns the KeyValue pair with the largest key in the
+// Map, returning false if the Map is empty.
+func (m *Map[K, V]) PopMax() (KeyValue[K, V], bool) {
+	m.check(rw)
+
+	if len(m.keys) == 0 {
+		return KeyValue[K, V]{}, false
+	}
+
+	i := len(m.keys) - 1
+	kv := m.kv(i)
+
+	// Zero the removed key and value so they can be garbage collected.
+	var (
+		zk K
+		zv V
+	)
+	m.keys[i], m.vals[i] = zk, zv
+	m.keys, m.vals = m.keys[:i], m.vals[:i]
+	delete(m.m, kv.Key)
+
+	return kv, true
 }
 
 // Reset clears the underlying storage for a Map by removing all elements,
-// enabling the allocated capacity to be reused.
+// enabling the allocated capacity to be reused. To release the storage instead,
+// use Clear.
 func (m *Map[K, V]) Reset() {
 	m.check(rw)
 
 	m.keys = m.keys[:0]
+	clear(m.vals)
+	m.vals = m.vals[:0]
 	clear(m.m)
 }
 
+// Clear removes all elements from a Map and releases its underlying storage so
+// that the memory can be reclaimed by the garbage collector. Unlike Reset,
+// which keeps the allocated capacity for reuse, the Map must allocate new
+// storage as elements are inserted after Clear.
+func (m *Map[K, V]) Clear() {
+	m.check(rw)
+
+	m.keys = nil
+	m.vals = nil
+	m.m = make(map[K]V)
+}
+
+// Grow increases the Map's capacity, if necessary, to guarantee space for
+// another n elements. n must not be negative or Grow will panic.
+//
+// Because a Go map cannot be grown in place, Grow copies the existing elements
+// into larger underlying storage when the Map lacks space for n more elements.
+// If the Map already has enough spare capacity, Grow does nothing.
+func (m *Map[K, V]) Grow(n int) {
+	m.check(rw)
+
+	if n < 0 {
+		panic("ordered: Map.Grow must use a non-negative n")
+	}
+	if cap(m.keys)-len(m.keys) >= n && cap(m.vals)-len(m.vals) >= n {
+		return
+	}
+
+	m.keys = slices.Grow(m.keys, n)
+	m.vals = slices.Grow(m.vals, n)
+
+	mm := make(map[K]V, len(m.m)+n)
+	for k, v := range m.m {
+		mm[k] = v
+	}
+	m.m = mm
+}
+
+// Compact reallocates the underlying storage for a Map to fit its current
+// elements, releasing memory retained after many elements are deleted.
+func (m *Map[K, V]) Compact() {
+	m.check(rw)
+
+	keys := make([]K, len(m.keys))
+	copy(keys, m.keys)
+	m.keys = keys
+
+	vals := make([]V, len(m.vals))
+	copy(vals, m.vals)
+	m.vals = vals
+
+	// Go maps do not shrink after deletions, so copy the elements into a new
+	// map sized for the current elements.
+	mm := make(map[K]V, len(m.m))
+	for k, v := range m.m {
+		mm[k] = v
+	}
+	m.m = mm
+}
+
+// Clone returns a shallow copy of a Map which uses the same comparison
+// function. Modifications to the clone do not affect the original Map.
+func (m *Map[K, V]) Clone() *Map[K, V] {
+	m.check(ro)
+
+	// The keys are already sorted, so they can be copied directly.
+	return &Map[K, V]{
+		keys: slices.Clone(m.keys),
+		vals: slices.Clone(m.vals),
+		cmp:  m.cmp,
+		m:    maps.Clone(m.m),
+	}
+}
+
+// WithComparator returns a shallow copy of a Map which orders its keys using
+// cmp. See NewMap for details on cmp. The original Map is not modified.
+func (m *Map[K, V]) WithComparator(cmp func(a, b K) int) *Map[K, V] {
+	m.check(ro)
+	if cmp == nil {
+		panic("ordered: Map.WithComparator must use a non-nil cmp function")
+	}
+
+	out := &Map[K, V]{
+		keys: make([]K, 0, len(m.keys)),
+		vals: make([]V, 0, len(m.vals)),
+		cmp:  cmp,
+		m:    maps.Clone(m.m),
+	}
+
+	out.sortKeys()
+	return out
+}
+
+// Freeze makes the Map permanently immutable: any later calls to methods which
+// write to the Map, including MapIterator.Remove, will panic. Reads and
+// iteration remain permitted. Clones of a frozen Map are not frozen.
+//
+// Because a frozen Map cannot be written, it is safe for concurrent reads.
+func (m *Map[K, V]) Freeze() {
+	m.check(ro)
+	m.frozen = true
+}
+
 // check checks the Map's invariants for a given operation type.
 func (m *Map[K, V]) check(op op) {
 	if m == nil || m.cmp == nil {
 		panic("ordered: a Map must be constructed using NewMap")
 	}
 
+	if op == rw && m.frozen {
+		panic("ordered: write to frozen Map")
+	}
+
 	if op == rw && atomic.LoadInt32(&m.iter) != 0 {
-		panic("ordered: write to Map while MapIterator is not closed")
+		panic("ordered: write to Map during iteration")
+	}
+}
+
+// verify verifies that the Map's keys are sorted by its comparison function
+// and that the order index is consistent with the underlying map storage. A
+// failure indicates that the comparison function does not define a strict
+// weak ordering or reports distinct keys as equal. verify is only called when
+// built with -tags ordereddebug.
+func (m *Map[K, V]) verify() {
+	if len(m.keys) != len(m.m) || len(m.vals) != len(m.m) {
+		panic(fmt.Sprintf(
+			"ordered: inconsistent Map storage: %d keys and %d values in order index, %d in map; the comparison function may report distinct keys as equal",
+			len(m.keys), len(m.vals), len(m.m),
+		))
+	}
+
+	for i := 1; i < len(m.keys); i++ {
+		if a, b := m.keys[i-1], m.keys[i]; m.cmp(a, b) >= 0 {
+			panic(fmt.Sprintf(
+				"ordered: inconsistent comparison function: key %v at index %d does not sort before key %v at index %d",
+				a, i-1, b, i,
+			))
+		}
 	}
 }
 
@@ -128,18 +700,379 @@ type KeyValue[K comparable, V any] struct {
 // loop. See Map.Iter for more fine-grained iteration control.
 func (m *Map[K, V]) Range() []KeyValue[K, V] {
 	m.check(ro)
+	return m.RangeInto(make([]KeyValue[K, V], 0, len(m.keys)))
+}
 
-	kvs := make([]KeyValue[K, V], 0, len(m.keys))
-	for _, k := range m.keys {
-		kvs = append(kvs, KeyValue[K, V]{
-			Key:   k,
-			Value: m.m[k],
-		})
+// RangeByValue produces a slice of all KeyValue pairs from Map ordered by their
+// values using cmp, with pairs which have equal values ordered by their keys.
+// See NewMap for details on cmp.
+func (m *Map[K, V]) RangeByValue(cmp func(a, b V) int) []KeyValue[K, V] {
+	m.check(ro)
+
+	// The pairs are already ordered by key, so a stable sort preserves key order
+	// for equal values.
+	kvs := m.Range()
+	slices.SortStableFunc(kvs, func(a, b KeyValue[K, V]) int {
+		return cmp(a.Value, b.Value)
+	})
+
+	return kvs
+}
+
+// RangeInto appends all KeyValue pairs from Map to dst in sorted order and
+// returns the extended slice, growing dst as append would. RangeInto allows
+// callers to reuse a slice across calls to avoid allocating.
+func (m *Map[K, V]) RangeInto(dst []KeyValue[K, V]) []KeyValue[K, V] {
+	m.check(ro)
+
+	dst = slices.Grow(dst, len(m.keys))
+	for i := range m.keys {
+		dst = append(dst, m.kv(i))
+	}
+
+	return dst
+}
+
+// Chunk produces slices of at most n KeyValue pairs each from Map in sorted
+// order. The final slice may contain fewer than n pairs. n must be positive or
+// Chunk will panic.
+func (m *Map[K, V]) Chunk(n int) [][]KeyValue[K, V] {
+	m.check(ro)
+
+	if n <= 0 {
+		panic("ordered: Map.Chunk must use a positive n")
+	}
+
+	// All chunks share a single backing slice, clipped so that appending to
+	// one chunk cannot overwrite the next.
+	kvs := m.Range()
+	chunks := make([][]KeyValue[K, V], 0, (len(kvs)+n-1)/n)
+	for i := 0; i < len(kvs); i += n {
+		j := min(i+n, len(kvs))
+		chunks = append(chunks, kvs[i:j:j])
+	}
+
+	return chunks
+}
+
+// Keys produces a slice of all keys K from Map in sorted order.
+func (m *Map[K, V]) Keys() []K {
+	m.check(ro)
+
+	keys := make([]K, 0, len(m.keys))
+	return append(keys, m.keys...)
+}
+
+// Values produces a slice of all values V from Map, ordered by their keys.
+func (m *Map[K, V]) Values() []V {
+	m.check(ro)
+
+	vals := make([]V, 0, len(m.vals))
+	return append(vals, m.vals...)
+}
+
+// KeysInto appends all keys K from Map to dst in sorted order and returns the
+// extended slice, growing dst as append would. The returned slice may share its
+// underlying array with dst.
+func (m *Map[K, V]) KeysInto(dst []K) []K {
+	m.check(ro)
+	return append(dst, m.keys...)
+}
+
+// ValuesInto appends all values V from Map to dst, ordered by their keys, and
+// returns the extended slice, growing dst as append would. The returned slice
+// may share its underlying array with dst.
+func (m *Map[K, V]) ValuesInto(dst []V) []V {
+	m.check(ro)
+	return append(dst, m.vals...)
+}
+
+// CloneFunc is like Clone, but produces each value in the copy by calling
+// copyVal on the corresponding value in m. CloneFunc can be used to produce a
+// deep copy of a Map which stores reference types such as pointers or slices.
+func (m *Map[K, V]) CloneFunc(copyVal func(V) V) *Map[K, V] {
+	return MapValues(m, func(_ K, v V) V { return copyVal(v) })
+}
+
+// SubMap produces a new Map which uses the same comparison function as m and
+// contains a copy of the KeyValue pairs with keys in the half-open interval
+// [lo, hi). If lo is greater than or equal to hi, the new Map is empty.
+func (m *Map[K, V]) SubMap(lo, hi K) *Map[K, V] {
+	m.check(ro)
+
+	i, j := m.interval(lo, hi)
+	return m.slice(i, j)
+}
+
+// CountRange returns the number of keys in the half-open interval [lo, hi).
+// If lo is greater than or equal to hi, CountRange returns 0. CountRange is
+// O(log n).
+func (m *Map[K, V]) CountRange(lo, hi K) int {
+	m.check(ro)
+
+	i, j := m.interval(lo, hi)
+	return j - i
+}
+
+// interval returns the index interval [i, j) of the keys in the half-open key
+// interval [lo, hi). If lo is greater than or equal to hi, i equals j.
+func (m *Map[K, V]) interval(lo, hi K) (i, j int) {
+	i, _ = m.search(lo)
+	j, _ = m.search(hi)
+	if j < i {
+		// lo > hi, so the interval is empty.
+		j = i
+	}
+
+	return i, j
+}
+
+// HeadMap produces a new Map which uses the same comparison function as m and
+// contains a copy of the KeyValue pairs with keys strictly less than k.
+func (m *Map[K, V]) HeadMap(k K) *Map[K, V] {
+	m.check(ro)
+
+	i, _ := m.search(k)
+	return m.slice(0, i)
+}
+
+// TailMap produces a new Map which uses the same comparison function as m and
+// contains a copy of the KeyValue pairs with keys greater than or equal to k.
+func (m *Map[K, V]) TailMap(k K) *Map[K, V] {
+	m.check(ro)
+
+	i, _ := m.search(k)
+	return m.slice(i, len(m.keys))
+}
+
+// Split produces two new Maps which use the same comparison function as m:
+// lower contains a copy of the KeyValue pairs with keys strictly less than k,
+// and upper contains a copy of those with keys greater than or equal to k.
+// Split is equivalent to calling both HeadMap and TailMap, but searches for k
+// only once.
+func (m *Map[K, V]) Split(k K) (lower, upper *Map[K, V]) {
+	m.check(ro)
+
+	i, _ := m.search(k)
+	return m.slice(0, i), m.slice(i, len(m.keys))
+}
+
+// slice produces a new Map which uses the same comparison function as m and
+// contains a copy of the KeyValue pairs for the keys in m.keys[i:j].
+func (m *Map[K, V]) slice(i, j int) *Map[K, V] {
+	// The keys are already sorted, so they can be copied directly.
+	out := NewMapWithCapacity[K, V](m.cmp, j-i)
+	out.keys = append(out.keys, m.keys[i:j]...)
+	out.vals = append(out.vals, m.vals[i:j]...)
+	for i, k := range out.keys {
+		out.m[k] = out.vals[i]
+	}
+
+	return out
+}
+
+// Min returns the KeyValue pair with the smallest key in the Map, returning
+// false if the Map is empty.
+func (m *Map[K, V]) Min() (KeyValue[K, V], bool) {
+	m.check(ro)
+
+	if len(m.keys) == 0 {
+		return KeyValue[K, V]{}, false
+	}
+
+	return m.kv(0), true
+}
+
+// Max returns the KeyValue pair with the largest key in the Map, returning
+// false if the Map is empty.
+func (m *Map[K, V]) Max() (KeyValue[K, V], bool) {
+	m.check(ro)
+
+	if len(m.keys) == 0 {
+		return KeyValue[K, V]{}, false
+	}
+
+	return m.kv(len(m.keys) - 1), true
+}
+
+// Bounds returns the smallest and largest keys in the Map, returning false if
+// the Map is empty.
+func (m *Map[K, V]) Bounds() (min, max K, ok bool) {
+	m.check(ro)
+
+	if len(m.keys) == 0 {
+		return min, max, false
+	}
+
+	return m.keys[0], m.keys[len(m.keys)-1], true
+}
+
+// At returns the KeyValue pair at index i in the sorted order of the Map's
+// keys. At panics if i is out of range.
+func (m *Map[K, V]) At(i int) KeyValue[K, V] {
+	m.check(ro)
+
+	if i < 0 || i >= len(m.keys) {
+		panic(fmt.Sprintf("ordered: Map.At index %d out of range [0:%d]", i, len(m.keys)))
+	}
+
+	return m.kv(i)
+}
+
+// Select produces a slice of the KeyValue pairs at indices [i, j) in the sorted
+// order of the Map's keys. Select panics if i or j is out of range or if i is
+// greater than j.
+func (m *Map[K, V]) Select(i, j int) []KeyValue[K, V] {
+	m.check(ro)
+
+	if i < 0 || j > len(m.keys) || i > j {
+		panic(fmt.Sprintf("ordered: Map.Select indices [%d:%d] out of range [0:%d]", i, j, len(m.keys)))
+	}
+
+	kvs := make([]KeyValue[K, V], 0, j-i)
+	for ; i < j; i++ {
+		kvs = append(kvs, m.kv(i))
 	}
 
 	return kvs
 }
 
+// IndexOf returns the index of k in the sorted order of the Map's keys, or -1
+// if k is not found.
+func (m *Map[K, V]) IndexOf(k K) int {
+	m.check(ro)
+
+	i, ok := m.search(k)
+	if !ok {
+		return -1
+	}
+
+	return i
+}
+
+// RankOf returns the number of keys in the Map strictly less than k. Unlike
+// IndexOf, RankOf does not require k to be present in the Map: for a key which
+// is present, RankOf and IndexOf return the same value. RankOf is O(log n).
+func (m *Map[K, V]) RankOf(k K) int {
+	m.check(ro)
+
+	i, _ := m.search(k)
+	return i
+}
+
+// PeekMin is like PopMin, but does not remove the KeyValue pair with the
+// smallest key from the Map. PeekMin is an alias for Min.
+func (m *Map[K, V]) PeekMin() (KeyValue[K, V], bool) {
+	return m.Min()
+}
+
+// PeekMax is like PopMax, but does not remove the KeyValue pair with the
+// largest key from the Map. PeekMax is an alias for Max.
+func (m *Map[K, V]) PeekMax() (KeyValue[K, V], bool) {
+	return m.Max()
+}
+
+// Floor returns the KeyValue pair with the largest key less than or equal to
+// k, returning false if no such key exists.
+func (m *Map[K, V]) Floor(k K) (KeyValue[K, V], bool) {
+	m.check(ro)
+
+	i, ok := m.search(k)
+	switch {
+	case ok:
+		return m.kv(i), true
+	case i == 0:
+		return KeyValue[K, V]{}, false
+	default:
+		return m.kv(i - 1), true
+	}
+}
+
+// Ceiling returns the KeyValue pair with the smallest key greater than or
+// equal to k, returning false if no such key exists.
+func (m *Map[K, V]) Ceiling(k K) (KeyValue[K, V], bool) {
+	m.check(ro)
+
+	i, _ := m.search(k)
+	if i == len(m.keys) {
+		return KeyValue[K, V]{}, false
+	}
+
+	return m.kv(i), true
+}
+
+// Higher returns the KeyValue pair with the smallest key strictly greater
+// than k, returning false if no such key exists.
+func (m *Map[K, V]) Higher(k K) (KeyValue[K, V], bool) {
+	m.check(ro)
+
+	i, ok := m.search(k)
+	if ok {
+		// Skip the exact match.
+		i++
+	}
+	if i == len(m.keys) {
+		return KeyValue[K, V]{}, false
+	}
+
+	return m.kv(i), true
+}
+
+// Lower returns the KeyValue pair with the largest key strictly less than k,
+// returning false if no such key exists.
+func (m *Map[K, V]) Lower(k K) (KeyValue[K, V], bool) {
+	m.check(ro)
+
+	i, _ := m.search(k)
+	if i == 0 {
+		return KeyValue[K, V]{}, false
+	}
+
+	return m.kv(i - 1), true
+}
+
+// Nearest returns the KeyValue pair with the key nearest to k, returning false
+// if the Map is empty. dist reports the non-negative distance between two
+// keys, and is only called with k and the keys immediately surrounding k. If
+// k is found, its pair is returned. If the keys below and above k are equally
+// distant from k, the pair with the smaller key is returned.
+func (m *Map[K, V]) Nearest(k K, dist func(a, b K) int) (KeyValue[K, V], bool) {
+	m.check(ro)
+
+	i, ok := m.search(k)
+	switch {
+	case ok:
+		return m.kv(i), true
+	case len(m.keys) == 0:
+		return KeyValue[K, V]{}, false
+	case i == 0:
+		return m.kv(i), true
+	case i == len(m.keys):
+		return m.kv(i - 1), true
+	}
+
+	// k lies between the keys at i-1 and i.
+	if dist(k, m.keys[i-1]) <= dist(k, m.keys[i]) {
+		return m.kv(i - 1), true
+	}
+
+	return m.kv(i), true
+}
+
+// search binary searches the sorted keys for k, returning the index where k is
+// or would be inserted and whether k was found.
+func (m *Map[K, V]) search(k K) (int, bool) {
+	return slices.BinarySearchFunc(m.keys, k, m.cmp)
+}
+
+// kv produces the KeyValue pair for the key at index i.
+func (m *Map[K, V]) kv(i int) KeyValue[K, V] {
+	return KeyValue[K, V]{
+		Key:   m.keys[i],
+		Value: m.vals[i],
+	}
+}
+
 // A MapIterator is an iteration cursor over a Map. A MapIterator must be
 // constructed using Map.Iter or its methods will panic.
 //
@@ -148,34 +1081,120 @@ func (m *Map[K, V]) Range() []KeyValue[K, V] {
 // iteration and permit further writes, call MapIterator.Close. Multiple
 // MapIterators can be used at once over the same Map, but write methods will
 // panic until all MapIterators are closed. After a call to Close, the
-// MapIterator can no longer be used.
+// MapIterator can no longer be used and its methods will panic.
+//
+// The same applies to range loops over sequences such as Map.All and to
+// callback methods such as Map.ForEach: writes to the Map panic until the loop
+// or callback returns.
+//
+// Close should be deferred immediately after a MapIterator is created, so that
+// the Map remains writable even if a panic occurs during iteration. See
+// Map.ResetIterators for recovering a Map when Close was not called.
 //
 // For more basic iteration use cases, see Map.Range.
 type MapIterator[K comparable, V any] struct {
 	m *Map[K, V]
 	i int
+
+	// The iteration position of the KeyValue pair last returned by Next or
+	// Prev, or -1 if there is no such pair.
+	last int
+
+	// Whether or not to iterate in descending key order, and whether or not
+	// the MapIterator has been closed.
+	reverse, closed bool
+
+	// The Map's ResetIterators count when the MapIterator was opened.
+	gen int32
 }
 
 // Iter produces a MapIterator which allows fine-grained iteration over a Map.
 func (m *Map[K, V]) Iter() *MapIterator[K, V] {
 	m.check(ro)
+	return m.newIterator(false)
+}
+
+// IterReverse is like Iter, but produces a MapIterator which iterates over a
+// Map in descending key order.
+func (m *Map[K, V]) IterReverse() *MapIterator[K, V] {
+	m.check(ro)
+	return m.newIterator(true)
+}
+
+// IterFrom is like Iter, but produces a MapIterator which begins iteration at
+// the smallest key greater than or equal to k.
+func (m *Map[K, V]) IterFrom(k K) *MapIterator[K, V] {
+	m.check(ro)
+
+	mi := m.newIterator(false)
+	mi.i, _ = m.search(k)
+	return mi
+}
 
+// newIterator produces a MapIterator and marks it as live for this Map.
+func (m *Map[K, V]) newIterator(reverse bool) *MapIterator[K, V] {
 	// Add another iterator to the stack.
 	atomic.AddInt32(&m.iter, 1)
-	return &MapIterator[K, V]{m: m}
+	return &MapIterator[K, V]{
+		m:       m,
+		last:    -1,
+		reverse: reverse,
+		gen:     atomic.LoadInt32(&m.gen),
+	}
+}
+
+// beginIter marks the Map as being iterated, as if a MapIterator were open,
+// so that writes to the Map from a range loop body or callback panic rather
+// than invalidating the iteration. It returns the Map's ResetIterators count,
+// which must be passed to endIter when the iteration completes.
+func (m *Map[K, V]) beginIter() int32 {
+	atomic.AddInt32(&m.iter, 1)
+	return atomic.LoadInt32(&m.gen)
+}
+
+// endIter completes an iteration started by beginIter.
+func (m *Map[K, V]) endIter(gen int32) {
+	if gen != atomic.LoadInt32(&m.gen) {
+		// ResetIterators already removed this iteration from the stack.
+		return
+	}
+
+	atomic.AddInt32(&m.iter, -1)
+}
+
+// ResetIterators forcibly marks all open MapIterators for a Map as closed,
+// enabling further writes to the Map. ResetIterators is an escape hatch for
+// recovering a Map after a MapIterator was leaked, such as when a panic occurs
+// during iteration and Close was not deferred. Prefer deferring Close, which
+// is always safe.
+//
+// WARNING: MapIterators which were open when ResetIterators was called remain
+// usable, but may skip or repeat pairs if the Map is written. Calling Close on
+// such a MapIterator has no effect on the Map.
+func (m *Map[K, V]) ResetIterators() {
+	m.check(ro)
+
+	atomic.AddInt32(&m.gen, 1)
+	atomic.StoreInt32(&m.iter, 0)
 }
 
 // Close releases a MapIterator's resources, enabling further writes to a Map.
 func (mi *MapIterator[K, V]) Close() {
 	mi.check()
 
+	if mi.gen != atomic.LoadInt32(&mi.m.gen) {
+		// Map.ResetIterators already removed this iterator from the stack.
+		mi.closed = true
+		return
+	}
+
 	// Remove an iterator from the stack. If this number goes below zero, panic
 	// due to misuse.
 	if atomic.AddInt32(&mi.m.iter, -1) < 0 {
 		panic("ordered: call to MapIterator.Close while MapIterator is not open")
 	}
 
-	mi = nil
+	mi.closed = true
 }
 
 // Next returns the next KeyValue pair from a Map. If Next returns nil, no more
@@ -188,20 +1207,135 @@ func (mi *MapIterator[K, V]) Close() {
 //	    // use kv
 //	}
 func (mi *MapIterator[K, V]) Next() *KeyValue[K, V] {
+	kv, ok := mi.NextOK()
+	if !ok {
+		return nil
+	}
+
+	return &kv
+}
+
+// NextOK is like Next, but returns the next KeyValue pair by value to avoid an
+// allocation per call. If NextOK returns false, no more KeyValue pairs are
+// present. NextOK is intended to be used in a for loop, in the format:
+//
+//	mi := m.Iter()
+//	defer mi.Close()
+//	for kv, ok := mi.NextOK(); ok; kv, ok = mi.NextOK() {
+//	    // use kv
+//	}
+func (mi *MapIterator[K, V]) NextOK() (KeyValue[K, V], bool) {
 	mi.check()
 
 	if mi.i >= len(mi.m.keys) {
 		// No more keys.
-		return nil
+		return KeyValue[K, V]{}, false
 	}
 
-	k := mi.m.keys[mi.i]
+	kv := mi.m.kv(mi.index(mi.i))
+	mi.last = mi.i
 	mi.i++
 
-	return &KeyValue[K, V]{
-		Key:   k,
-		Value: mi.m.m[k],
+	return kv, true
+}
+
+// Prev returns the previous KeyValue pair from a Map. If Prev returns nil, the
+// MapIterator is positioned before the first KeyValue pair.
+//
+// A MapIterator is always positioned between two KeyValue pairs: Next returns
+// the pair after that position and advances, while Prev returns the pair before
+// that position and moves back. Therefore, calling Prev after Next returns the
+// same pair that Next returned, and vice versa. Calling Prev after Next has
+// returned nil produces the final pair in iteration order.
+func (mi *MapIterator[K, V]) Prev() *KeyValue[K, V] {
+	mi.check()
+
+	if mi.i <= 0 {
+		// No previous keys.
+		return nil
 	}
+
+	mi.i--
+	mi.last = mi.i
+	kv := mi.m.kv(mi.index(mi.i))
+	return &kv
+}
+
+// Seek repositions a MapIterator so that the following call to Next returns the
+// KeyValue pair for k, or the pair with the next key in iteration order if k
+// is not found. For a MapIterator produced by Map.IterReverse, the next key in
+// iteration order is the next smaller key.
+func (mi *MapIterator[K, V]) Seek(k K) {
+	mi.check()
+	mi.last = -1
+
+	i, ok := mi.m.search(k)
+	if !mi.reverse {
+		mi.i = i
+		return
+	}
+
+	if !ok {
+		// Begin at the next smaller key.
+		i--
+	}
+	mi.i = mi.index(i)
+}
+
+// Reset rewinds a MapIterator so that the following call to Next returns the
+// first KeyValue pair in iteration order. Reset does not close the MapIterator.
+func (mi *MapIterator[K, V]) Reset() {
+	mi.check()
+	mi.i, mi.last = 0, -1
+}
+
+// Remove deletes the KeyValue pair last returned by Next or Prev from the Map,
+// and adjusts the MapIterator so that no other pairs are skipped during the
+// remainder of iteration. Remove panics if Next or Prev has not returned a pair
+// since the last call to Remove, Seek, or Reset.
+//
+// Remove is permitted while the MapIterator is open, but other iterations over
+// the same Map would not be aware of the removal. Therefore, Remove panics if
+// any other MapIterator, range loop, or callback iteration is in progress for
+// the Map.
+func (mi *MapIterator[K, V]) Remove() {
+	mi.check()
+
+	if mi.last < 0 {
+		panic("ordered: call to MapIterator.Remove without a pair from Next or Prev")
+	}
+	if mi.m.frozen {
+		panic("ordered: write to frozen Map")
+	}
+
+	// Exclude this MapIterator from the count of open iterations, unless
+	// ResetIterators already removed it.
+	others := atomic.LoadInt32(&mi.m.iter)
+	if mi.gen == atomic.LoadInt32(&mi.m.gen) {
+		others--
+	}
+	if others > 0 {
+		panic("ordered: call to MapIterator.Remove while another iteration over the Map is in progress")
+	}
+
+	mi.m.remove(mi.index(mi.last))
+
+	// Pairs after the removed pair in iteration order have shifted back by one
+	// position, regardless of the iteration direction.
+	if mi.i > mi.last {
+		mi.i--
+	}
+	mi.last = -1
+}
+
+// index converts an iteration position i into an index of the Map's sorted
+// keys.
+func (mi *MapIterator[K, V]) index(i int) int {
+	if mi.reverse {
+		return len(mi.m.keys) - 1 - i
+	}
+
+	return i
 }
 
 // check checks the MapIterator's invariants.
@@ -209,4 +1343,8 @@ func (mi *MapIterator[K, V]) check() {
 	if mi == nil || mi.m == nil {
 		panic("ordered: a MapIterator must be constructed using Map.Iter")
 	}
+
+	if mi.closed {
+		panic("ordered: use of MapIterator after Close")
+	}
 }
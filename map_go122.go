@@ -1,4 +1,4 @@
-//go:build go1.22 || go1.22rc1
+//go:build go1.22 && !go1.23
 
 package ordered
 
@@ -6,13 +6,23 @@ package ordered
 type seq2[K, V any] func(yield func(K, V) bool)
 
 // All yields key/value pairs from Map for use in a for-range loop with
-// GOEXPERIMENT=rangefunc.
+// GOEXPERIMENT=rangefunc. Writes to the Map panic for the duration of the
+// loop.
 func (m *Map[K, V]) All() seq2[K, V] {
 	return func(yield func(K, V) bool) {
-		for _, k := range m.keys {
-			if !yield(k, m.m[k]) {
+		defer m.endIter(m.beginIter())
+
+		for i, k := range m.keys {
+			if !yield(k, m.vals[i]) {
 				return
 			}
 		}
 	}
 }
+
+// All yields key/value pairs from the Map for use in a for-range loop with
+// GOEXPERIMENT=rangefunc.
+func (rv ReadView[K, V]) All() seq2[K, V] {
+	rv.check()
+	return rv.m.All()
+}
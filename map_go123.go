@@ -0,0 +1,93 @@
+//go:build go1.23
+
+package ordered
+
+import "iter"
+
+// Collect creates a *Map[K, V] which contains the key/value pairs from seq,
+// ordered by cmp. See NewMap for details on cmp. If a key K appears more than
+// once, the last pair wins. Collect sorts the keys once after seq is drained.
+func Collect[K comparable, V any](cmp func(a, b K) int, seq iter.Seq2[K, V]) *Map[K, V] {
+	if cmp == nil {
+		panic("ordered: Collect must use a non-nil cmp function")
+	}
+
+	m := NewMap[K, V](cmp)
+	for k, v := range seq {
+		m.m[k] = v
+	}
+
+	m.sortKeys()
+	return m
+}
+
+// All yields key/value pairs from Map in sorted order for use in a for range
+// loop. Writes to the Map panic for the duration of the loop.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	m.check(ro)
+
+	return func(yield func(K, V) bool) {
+		defer m.endIter(m.beginIter())
+
+		for i, k := range m.keys {
+			if !yield(k, m.vals[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward is like All, but yields key/value pairs from Map in descending key
+// order. Writes to the Map panic for the duration of the loop.
+func (m *Map[K, V]) Backward() iter.Seq2[K, V] {
+	m.check(ro)
+
+	return func(yield func(K, V) bool) {
+		defer m.endIter(m.beginIter())
+
+		for i := len(m.keys) - 1; i >= 0; i-- {
+			if !yield(m.keys[i], m.vals[i]) {
+				return
+			}
+		}
+	}
+}
+
+// KeysSeq yields keys from Map in sorted order for use in a for range loop.
+// Writes to the Map panic for the duration of the loop.
+func (m *Map[K, V]) KeysSeq() iter.Seq[K] {
+	m.check(ro)
+
+	return func(yield func(K) bool) {
+		defer m.endIter(m.beginIter())
+
+		for _, k := range m.keys {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// ValuesSeq yields values from Map, ordered by their keys, for use in a for
+// range loop. Writes to the Map panic for the duration of the loop.
+func (m *Map[K, V]) ValuesSeq() iter.Seq[V] {
+	m.check(ro)
+
+	return func(yield func(V) bool) {
+		defer m.endIter(m.beginIter())
+
+		for _, v := range m.vals {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// All yields key/value pairs from the Map in sorted order for use in a for
+// range loop. See Map.All for details.
+func (rv ReadView[K, V]) All() iter.Seq2[K, V] {
+	rv.check()
+	return rv.m.All()
+}
@@ -0,0 +1,240 @@
+//go:build go1.23
+
+package ordered_test
+
+import (
+	stdcmp "cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mdlayher/ordered"
+)
+
+func ExampleMap_All() {
+	m := ordered.NewMap[string, int](stdcmp.Compare[string])
+	m.Set("foo", 1)
+	m.Set("bar", 2)
+	m.Set("baz", 3)
+
+	for k, v := range m.All() {
+		fmt.Println(k, v)
+	}
+
+	// Output:
+	// bar 2
+	// baz 3
+	// foo 1
+}
+
+func TestCollect(t *testing.T) {
+	seq := func(yield func(string, int) bool) {
+		for _, kv := range []ordered.KeyValue[string, int]{
+			{Key: "foo", Value: 10},
+			{Key: "bar", Value: 2},
+			{Key: "baz", Value: 3},
+			{Key: "foo", Value: 1},
+		} {
+			if !yield(kv.Key, kv.Value) {
+				return
+			}
+		}
+	}
+
+	m := ordered.Collect(stdcmp.Compare, seq)
+	if diff := cmp.Diff(testMap().Range(), m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+
+	// Round trip through a built-in map.
+	src := map[string]int{"foo": 1, "bar": 2, "baz": 3}
+	if diff := cmp.Diff(testMap().Range(), ordered.Collect(stdcmp.Compare, maps.All(src)).Range()); diff != "" {
+		t.Fatalf("unexpected built-in map pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapAll(t *testing.T) {
+	m := testMap()
+
+	var got []string
+	for k := range m.All() {
+		if k == "foo" {
+			break
+		}
+
+		got = append(got, k)
+	}
+
+	if diff := cmp.Diff([]string{"bar", "baz"}, got); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+
+	want := map[string]int{"foo": 1, "bar": 2, "baz": 3}
+	if diff := cmp.Diff(want, maps.Collect(m.All())); diff != "" {
+		t.Fatalf("unexpected collected map (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapBackward(t *testing.T) {
+	m := testMap()
+
+	var got []ordered.KeyValue[string, int]
+	for k, v := range m.Backward() {
+		got = append(got, ordered.KeyValue[string, int]{Key: k, Value: v})
+	}
+
+	want := m.Range()
+	slices.Reverse(want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+
+	var keys []string
+	for k := range m.Backward() {
+		if k == "bar" {
+			break
+		}
+
+		keys = append(keys, k)
+	}
+
+	if diff := cmp.Diff([]string{"foo", "baz"}, keys); diff != "" {
+		t.Fatalf("unexpected keys after break (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapKeysSeq(t *testing.T) {
+	m := testMap()
+
+	if diff := cmp.Diff(m.Keys(), slices.Collect(m.KeysSeq())); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+
+	var got []string
+	for k := range m.KeysSeq() {
+		got = append(got, k)
+		break
+	}
+
+	if diff := cmp.Diff([]string{"bar"}, got); diff != "" {
+		t.Fatalf("unexpected keys after break (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapValuesSeq(t *testing.T) {
+	m := testMap()
+
+	if diff := cmp.Diff(m.Values(), slices.Collect(m.ValuesSeq())); diff != "" {
+		t.Fatalf("unexpected values (-want +got):\n%s", diff)
+	}
+
+	var got []int
+	for v := range m.ValuesSeq() {
+		got = append(got, v)
+		break
+	}
+
+	if diff := cmp.Diff([]int{2}, got); diff != "" {
+		t.Fatalf("unexpected values after break (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadViewAll(t *testing.T) {
+	m := testMap()
+
+	var got []ordered.KeyValue[string, int]
+	for k, v := range m.ReadOnly().All() {
+		got = append(got, ordered.KeyValue[string, int]{Key: k, Value: v})
+	}
+
+	if diff := cmp.Diff(m.Range(), got); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapSeqWritePanics(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(m *ordered.Map[string, int])
+	}{
+		{
+			name: "all",
+			fn: func(m *ordered.Map[string, int]) {
+				for k := range m.All() {
+					m.Delete(k)
+				}
+			},
+		},
+		{
+			name: "backward",
+			fn: func(m *ordered.Map[string, int]) {
+				for k := range m.Backward() {
+					m.Delete(k)
+				}
+			},
+		},
+		{
+			name: "keys",
+			fn: func(m *ordered.Map[string, int]) {
+				for k := range m.KeysSeq() {
+					m.Delete(k)
+				}
+			},
+		},
+		{
+			name: "values",
+			fn: func(m *ordered.Map[string, int]) {
+				for range m.ValuesSeq() {
+					m.Set("qux", 4)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testMap()
+			if !panics(t, func() { tt.fn(m) }) {
+				t.Fatal("expected write panic during range loop, but got none")
+			}
+
+			// Writes are permitted after the range loop completes, whether it
+			// ends early or not.
+			for range m.All() {
+				break
+			}
+			for range m.All() {
+			}
+			m.Set("qux", 4)
+
+			if diff := cmp.Diff([]string{"bar", "baz", "foo", "qux"}, m.Keys()); diff != "" {
+				t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMapIteratorRemoveDuringRangePanics(t *testing.T) {
+	m := testMap()
+
+	mi := m.Iter()
+	if !panics(t, func() {
+		for range m.All() {
+			mi.Next()
+			mi.Remove()
+		}
+	}) {
+		t.Fatal("expected remove panic during range loop, but got none")
+	}
+
+	// Once the range loop ends, the MapIterator may remove pairs again.
+	mi.Remove()
+	mi.Close()
+
+	if diff := cmp.Diff([]string{"baz", "foo"}, m.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+}
@@ -3,9 +3,13 @@ package ordered_test
 import (
 	stdcmp "cmp"
 	"fmt"
+	"math"
+	"slices"
+	"strconv"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/mdlayher/ordered"
 )
 
@@ -69,19 +73,37 @@ func TestMapBasics(t *testing.T) {
 		t.Fatalf("unexpected notfound OK value (-want +got):\n%s", diff)
 	}
 
+	if diff := cmp.Diff(aOK, m.Has("foo")); diff != "" {
+		t.Fatalf("unexpected foo Has value (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(bOK, m.Has("notfound")); diff != "" {
+		t.Fatalf("unexpected notfound Has value (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(1, m.GetOrDefault("foo", -1)); diff != "" {
+		t.Fatalf("unexpected foo default value (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(-1, m.GetOrDefault("notfound", -1)); diff != "" {
+		t.Fatalf("unexpected notfound default value (-want +got):\n%s", diff)
+	}
+
 	// foo is updated.
 	m.Set("foo", 10)
 	if diff := cmp.Diff(10, m.Get("foo")); diff != "" {
 		t.Fatalf("unexpected updated foo value (-want +got):\n%s", diff)
 	}
 
-	// Delete all but one key.
+	// Delete all but one key. Deleting a key which does not exist is a no-op.
 	m.Delete("bar")
 	m.Delete("baz")
+	m.Delete("notfound")
 
 	if diff := cmp.Diff(1, m.Len()); diff != "" {
 		t.Fatalf("unexpected post-delete length (-want +got):\n%s", diff)
 	}
+	if diff := cmp.Diff([]string{"foo"}, m.Keys()); diff != "" {
+		t.Fatalf("unexpected post-delete keys (-want +got):\n%s", diff)
+	}
 
 	// Clear the remaining keys.
 	m.Reset()
@@ -94,6 +116,406 @@ func TestMapBasics(t *testing.T) {
 	}
 }
 
+func TestMapComparator(t *testing.T) {
+	m := ordered.NewMap[string, int](ordered.Reverse(stdcmp.Compare[string]))
+
+	// A derived Map uses the same ordering.
+	derived := ordered.NewMap[string, int](m.Comparator())
+	derived.SetMany(testMap().Range()...)
+
+	if diff := cmp.Diff([]string{"foo", "baz", "bar"}, derived.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapFloatKeysNaN(t *testing.T) {
+	var (
+		nan  = math.NaN()
+		inf  = math.Inf(1)
+		want = []float64{nan, -inf, -1, 0, 1, inf}
+	)
+
+	// The order of the keys must not depend on the order in which they are
+	// inserted, even when one of those keys is NaN.
+	for _, perm := range [][]float64{
+		{nan, -inf, -1, 0, 1, inf},
+		{inf, 1, 0, -1, -inf, nan},
+		{0, nan, 1, -1, inf, -inf},
+	} {
+		m := ordered.NewMap[float64, int](stdcmp.Compare)
+		for i, k := range perm {
+			m.Set(k, i)
+		}
+
+		if diff := cmp.Diff(want, m.Keys(), cmpopts.EquateNaNs()); diff != "" {
+			t.Fatalf("unexpected keys for %v (-want +got):\n%s", perm, diff)
+		}
+
+		if m.Has(nan) {
+			t.Fatal("NaN key should not be retrievable")
+		}
+	}
+}
+
+func TestMapWithCapacity(t *testing.T) {
+	if diff := cmp.Diff(0, ordered.NewMap[string, int](stdcmp.Compare).Cap()); diff != "" {
+		t.Fatalf("unexpected empty capacity (-want +got):\n%s", diff)
+	}
+
+	m := ordered.NewMapWithCapacity[string, int](stdcmp.Compare, 3)
+	if diff := cmp.Diff(3, m.Cap()); diff != "" {
+		t.Fatalf("unexpected capacity (-want +got):\n%s", diff)
+	}
+
+	m.Set("foo", 1)
+	m.Set("bar", 2)
+	m.Set("baz", 3)
+
+	if diff := cmp.Diff(testMap().Range(), m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapOpts(t *testing.T) {
+	m := ordered.NewMapOpts(
+		ordered.WithCompareFunc[string, int](stdcmp.Compare),
+		ordered.WithCapacity[string, int](3),
+	)
+	m.Set("foo", 1)
+	m.Set("bar", 2)
+	m.Set("baz", 3)
+
+	if diff := cmp.Diff(testMap().Range(), m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapGrow(t *testing.T) {
+	m := testMap()
+	m.Grow(0)
+	m.Grow(100)
+
+	if c := m.Cap(); c < 103 {
+		t.Fatalf("capacity %d is too small after grow", c)
+	}
+
+	// Growing within the spare capacity is a no-op.
+	c := m.Cap()
+	m.Grow(50)
+	if diff := cmp.Diff(c, m.Cap()); diff != "" {
+		t.Fatalf("unexpected capacity after second grow (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(testMap().Range(), m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs after grow (-want +got):\n%s", diff)
+	}
+
+	for i := 0; i < 100; i++ {
+		m.Set(fmt.Sprintf("%03d", i), i)
+	}
+
+	if diff := cmp.Diff(103, m.Len()); diff != "" {
+		t.Fatalf("unexpected length after grow (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapPriorityQueue(t *testing.T) {
+	m := ordered.NewMap[int, string](stdcmp.Compare)
+	m.Push(3, "c")
+	m.Push(1, "a")
+	m.Push(4, "d")
+	m.Push(2, "b")
+
+	// Reads okay during iteration.
+	mi := m.Iter()
+	lo, _ := m.PeekMin()
+	hi, _ := m.PeekMax()
+	mi.Close()
+
+	if diff := cmp.Diff([]string{"a", "d"}, []string{lo.Value, hi.Value}); diff != "" {
+		t.Fatalf("unexpected peeked values (-want +got):\n%s", diff)
+	}
+
+	var got []string
+	for m.Len() > 0 {
+		lo, _ := m.PopMin()
+		hi, _ := m.PopMax()
+		got = append(got, lo.Value, hi.Value)
+	}
+
+	if _, ok := m.PeekMin(); ok {
+		t.Fatal("minimum peeked for empty queue")
+	}
+	if _, ok := m.PeekMax(); ok {
+		t.Fatal("maximum peeked for empty queue")
+	}
+
+	if diff := cmp.Diff([]string{"a", "d", "b", "c"}, got); diff != "" {
+		t.Fatalf("unexpected values (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapGetOrSet(t *testing.T) {
+	m := testMap()
+
+	v, ok := m.GetOrSet("foo", 10)
+	if diff := cmp.Diff(1, v); diff != "" {
+		t.Fatalf("unexpected foo value (-want +got):\n%s", diff)
+	}
+	if !ok {
+		t.Fatal("foo was not found")
+	}
+
+	v, ok = m.GetOrSet("aaa", 10)
+	if diff := cmp.Diff(10, v); diff != "" {
+		t.Fatalf("unexpected aaa value (-want +got):\n%s", diff)
+	}
+	if ok {
+		t.Fatal("aaa was found")
+	}
+
+	want := []ordered.KeyValue[string, int]{
+		{Key: "aaa", Value: 10},
+		{Key: "bar", Value: 2},
+		{Key: "baz", Value: 3},
+		{Key: "foo", Value: 1},
+	}
+
+	if diff := cmp.Diff(want, m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapSetIfAbsent(t *testing.T) {
+	m := testMap()
+
+	if m.SetIfAbsent("foo", 10) {
+		t.Fatal("foo was inserted")
+	}
+	if !m.SetIfAbsent("aaa", 10) {
+		t.Fatal("aaa was not inserted")
+	}
+
+	want := []ordered.KeyValue[string, int]{
+		{Key: "aaa", Value: 10},
+		{Key: "bar", Value: 2},
+		{Key: "baz", Value: 3},
+		{Key: "foo", Value: 1},
+	}
+
+	if diff := cmp.Diff(want, m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapUpdate(t *testing.T) {
+	m := testMap()
+
+	incr := func(old int, ok bool) int {
+		if !ok {
+			return 100
+		}
+		return old + 1
+	}
+
+	m.Update("foo", incr)
+	m.Update("aaa", incr)
+
+	want := []ordered.KeyValue[string, int]{
+		{Key: "aaa", Value: 100},
+		{Key: "bar", Value: 2},
+		{Key: "baz", Value: 3},
+		{Key: "foo", Value: 2},
+	}
+
+	if diff := cmp.Diff(want, m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapFromGoMap(t *testing.T) {
+	src := map[string]int{"foo": 1, "bar": 2, "baz": 3}
+	m := ordered.NewMapFromGoMap(src, stdcmp.Compare)
+
+	// Later changes to the source do not affect the Map.
+	src["qux"] = 4
+
+	if diff := cmp.Diff(testMap().Range(), m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+
+	// A nil source produces a usable Map.
+	empty := ordered.NewMapFromGoMap[string, int](nil, stdcmp.Compare)
+	empty.Set("foo", 1)
+
+	if !panics(t, func() { ordered.NewMapFromGoMap[string, int](nil, nil) }) {
+		t.Fatal("expected nil cmp panic, but got none")
+	}
+}
+
+func TestMapFromPairs(t *testing.T) {
+	m := ordered.NewMapFromPairs(stdcmp.Compare,
+		ordered.KeyValue[string, int]{Key: "foo", Value: 10},
+		ordered.KeyValue[string, int]{Key: "bar", Value: 2},
+		ordered.KeyValue[string, int]{Key: "baz", Value: 3},
+		ordered.KeyValue[string, int]{Key: "foo", Value: 1},
+	)
+
+	if diff := cmp.Diff(testMap().Range(), m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+
+	if !panics(t, func() { ordered.NewMapFromPairs[string, int](nil) }) {
+		t.Fatal("expected nil cmp panic, but got none")
+	}
+}
+
+func TestMapCompact(t *testing.T) {
+	m := ordered.NewMapWithCapacity[int, int](stdcmp.Compare, 100)
+	for i := 0; i < 100; i++ {
+		m.Set(i, i)
+	}
+	m.DeleteFunc(func(k, _ int) bool { return k >= 3 })
+	m.Compact()
+
+	if diff := cmp.Diff(3, m.Cap()); diff != "" {
+		t.Fatalf("unexpected capacity (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int{0, 1, 2}, m.Values()); diff != "" {
+		t.Fatalf("unexpected values (-want +got):\n%s", diff)
+	}
+
+	// The Map remains usable after compaction.
+	m.Set(3, 3)
+	if diff := cmp.Diff(4, m.Len()); diff != "" {
+		t.Fatalf("unexpected length (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapClear(t *testing.T) {
+	m := ordered.NewMapWithCapacity[int, int](stdcmp.Compare, 100)
+	for i := 0; i < 100; i++ {
+		m.Set(i, i)
+	}
+
+	// Reset retains capacity, while Clear releases it.
+	m.Reset()
+	if diff := cmp.Diff(100, m.Cap()); diff != "" {
+		t.Fatalf("unexpected capacity after reset (-want +got):\n%s", diff)
+	}
+
+	m.Set(0, 0)
+	m.Clear()
+	if diff := cmp.Diff(0, m.Cap()); diff != "" {
+		t.Fatalf("unexpected capacity after clear (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(0, m.Len()); diff != "" {
+		t.Fatalf("unexpected length after clear (-want +got):\n%s", diff)
+	}
+
+	// The Map remains usable after clearing.
+	m.Set(1, 1)
+	if diff := cmp.Diff([]ordered.KeyValue[int, int]{{Key: 1, Value: 1}}, m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapSetMany(t *testing.T) {
+	m := testMap()
+	m.SetMany(
+		ordered.KeyValue[string, int]{Key: "qux", Value: 4},
+		ordered.KeyValue[string, int]{Key: "foo", Value: 10},
+		ordered.KeyValue[string, int]{Key: "aaa", Value: 0},
+		ordered.KeyValue[string, int]{Key: "qux", Value: 40},
+	)
+
+	want := []ordered.KeyValue[string, int]{
+		{Key: "aaa", Value: 0},
+		{Key: "bar", Value: 2},
+		{Key: "baz", Value: 3},
+		{Key: "foo", Value: 10},
+		{Key: "qux", Value: 40},
+	}
+
+	if diff := cmp.Diff(want, m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapSetAll(t *testing.T) {
+	m := testMap()
+	m.SetAll(map[string]int{
+		"qux": 4,
+		"foo": 10,
+		"aaa": 0,
+	})
+
+	want := []ordered.KeyValue[string, int]{
+		{Key: "aaa", Value: 0},
+		{Key: "bar", Value: 2},
+		{Key: "baz", Value: 3},
+		{Key: "foo", Value: 10},
+		{Key: "qux", Value: 4},
+	}
+
+	if diff := cmp.Diff(want, m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapMerge(t *testing.T) {
+	m := testMap()
+
+	// Order other in reverse to verify the receiver's order is preserved.
+	other := ordered.NewMap[string, int](ordered.Reverse(stdcmp.Compare[string]))
+	other.Set("aaa", 0)
+	other.Set("foo", 10)
+	other.Set("qux", 4)
+
+	mi := other.Iter()
+	defer mi.Close()
+
+	m.Merge(other)
+
+	want := []ordered.KeyValue[string, int]{
+		{Key: "aaa", Value: 0},
+		{Key: "bar", Value: 2},
+		{Key: "baz", Value: 3},
+		{Key: "foo", Value: 10},
+		{Key: "qux", Value: 4},
+	}
+
+	if diff := cmp.Diff(want, m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapMergeFunc(t *testing.T) {
+	m := testMap()
+
+	other := ordered.NewMap[string, int](stdcmp.Compare)
+	other.Set("foo", 10)
+	other.Set("qux", 4)
+
+	m.MergeFunc(other, func(k string, a, b int) int {
+		if k != "foo" {
+			panic("resolve called for " + k)
+		}
+		return a + b
+	})
+
+	want := []ordered.KeyValue[string, int]{
+		{Key: "bar", Value: 2},
+		{Key: "baz", Value: 3},
+		{Key: "foo", Value: 11},
+		{Key: "qux", Value: 4},
+	}
+
+	if diff := cmp.Diff(want, m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+}
+
 func TestMapRange(t *testing.T) {
 	m := testMap()
 
@@ -116,35 +538,987 @@ func TestMapRange(t *testing.T) {
 	}
 }
 
-func TestMapIterate(t *testing.T) {
+func TestMapRangeByValue(t *testing.T) {
 	m := testMap()
-	m.Set("zzz", 999)
+	m.Set("aaa", 2)
+	m.Set("qux", 1)
+
+	// Pairs with equal values are ordered by key.
+	want := []ordered.KeyValue[string, int]{
+		{Key: "baz", Value: 3},
+		{Key: "aaa", Value: 2},
+		{Key: "bar", Value: 2},
+		{Key: "foo", Value: 1},
+		{Key: "qux", Value: 1},
+	}
+
+	got := m.RangeByValue(ordered.Reverse(stdcmp.Compare[int]))
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+
+	// The Map's own order is unaffected.
+	if diff := cmp.Diff([]string{"aaa", "bar", "baz", "foo", "qux"}, m.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapRangeInto(t *testing.T) {
+	m := testMap()
+
+	// Reads okay during iteration.
+	mi := m.Iter()
+	defer mi.Close()
+
+	// Entries are appended after any existing elements.
+	buf := []ordered.KeyValue[string, int]{{Key: "zzz", Value: 0}}
+	buf = m.RangeInto(buf)
+
+	want := []ordered.KeyValue[string, int]{
+		{Key: "zzz", Value: 0},
+		{Key: "bar", Value: 2},
+		{Key: "baz", Value: 3},
+		{Key: "foo", Value: 1},
+	}
+
+	if diff := cmp.Diff(want, buf); diff != "" {
+		t.Fatalf("unexpected appended pairs (-want +got):\n%s", diff)
+	}
+
+	// A reused buffer with sufficient capacity must not allocate.
+	buf = buf[:0]
+	if n := testing.AllocsPerRun(10, func() { buf = m.RangeInto(buf[:0]) }); n != 0 {
+		t.Fatalf("unexpected allocations with reused buffer: %v", n)
+	}
+
+	if diff := cmp.Diff(m.Range(), buf); diff != "" {
+		t.Fatalf("unexpected reused pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapChunk(t *testing.T) {
+	m := testMap()
+	m.Set("qux", 4)
+
+	tests := []struct {
+		name string
+		n    int
+		want [][]ordered.KeyValue[string, int]
+	}{
+		{
+			name: "one",
+			n:    1,
+			want: [][]ordered.KeyValue[string, int]{
+				{{Key: "bar", Value: 2}},
+				{{Key: "baz", Value: 3}},
+				{{Key: "foo", Value: 1}},
+				{{Key: "qux", Value: 4}},
+			},
+		},
+		{
+			name: "uneven",
+			n:    3,
+			want: [][]ordered.KeyValue[string, int]{
+				{{Key: "bar", Value: 2}, {Key: "baz", Value: 3}, {Key: "foo", Value: 1}},
+				{{Key: "qux", Value: 4}},
+			},
+		},
+		{
+			name: "larger",
+			n:    10,
+			want: [][]ordered.KeyValue[string, int]{
+				{{Key: "bar", Value: 2}, {Key: "baz", Value: 3}, {Key: "foo", Value: 1}, {Key: "qux", Value: 4}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, m.Chunk(tt.n)); diff != "" {
+				t.Fatalf("unexpected chunks (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// Appending to a chunk must not modify the next chunk.
+	chunks := m.Chunk(2)
+	_ = append(chunks[0], ordered.KeyValue[string, int]{Key: "zzz"})
+	if diff := cmp.Diff("foo", chunks[1][0].Key); diff != "" {
+		t.Fatalf("unexpected next chunk key (-want +got):\n%s", diff)
+	}
+
+	if chunks := ordered.NewMap[string, int](stdcmp.Compare).Chunk(1); len(chunks) != 0 {
+		t.Fatalf("unexpected empty map chunks: %#v", chunks)
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	m := testMap()
+
+	// Reads okay during iteration.
+	mi := m.Iter()
+	defer mi.Close()
+
+	if diff := cmp.Diff([]string{"bar", "baz", "foo"}, m.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+
+	// Empty maps produce empty, non-nil slices.
+	if keys := ordered.NewMap[string, int](stdcmp.Compare).Keys(); keys == nil || len(keys) != 0 {
+		t.Fatalf("unexpected empty map keys: %#v", keys)
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	m := testMap()
+
+	// Reads okay during iteration.
+	mi := m.Iter()
+	defer mi.Close()
+
+	if diff := cmp.Diff([]int{2, 3, 1}, m.Values()); diff != "" {
+		t.Fatalf("unexpected values (-want +got):\n%s", diff)
+	}
+
+	// Empty maps produce empty, non-nil slices.
+	if vals := ordered.NewMap[string, int](stdcmp.Compare).Values(); vals == nil || len(vals) != 0 {
+		t.Fatalf("unexpected empty map values: %#v", vals)
+	}
+}
+
+func TestMapKeysValuesInto(t *testing.T) {
+	m := testMap()
+
+	// Reads okay during iteration.
+	mi := m.Iter()
+	defer mi.Close()
+
+	keys := m.KeysInto([]string{"zzz"})
+	if diff := cmp.Diff([]string{"zzz", "bar", "baz", "foo"}, keys); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+
+	vals := m.ValuesInto([]int{0})
+	if diff := cmp.Diff([]int{0, 2, 3, 1}, vals); diff != "" {
+		t.Fatalf("unexpected values (-want +got):\n%s", diff)
+	}
+
+	// Reused buffers with sufficient capacity must not allocate.
+	n := testing.AllocsPerRun(10, func() {
+		keys = m.KeysInto(keys[:0])
+		vals = m.ValuesInto(vals[:0])
+	})
+	if n != 0 {
+		t.Fatalf("unexpected allocations with reused buffers: %v", n)
+	}
+
+	if diff := cmp.Diff(m.Keys(), keys); diff != "" {
+		t.Fatalf("unexpected reused keys (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(m.Values(), vals); diff != "" {
+		t.Fatalf("unexpected reused values (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapClone(t *testing.T) {
+	m := testMap()
+
+	// Reads okay during iteration.
+	mi := m.Iter()
+	c := m.Clone()
+	mi.Close()
+
+	if diff := cmp.Diff(m.Range(), c.Range()); diff != "" {
+		t.Fatalf("unexpected clone contents (-want +got):\n%s", diff)
+	}
+
+	// Modifying the clone must not affect the original.
+	c.Set("aaa", 0)
+	c.Set("foo", 10)
+	c.Delete("bar")
+
+	want := []ordered.KeyValue[string, int]{
+		{Key: "bar", Value: 2},
+		{Key: "baz", Value: 3},
+		{Key: "foo", Value: 1},
+	}
+
+	if diff := cmp.Diff(want, m.Range()); diff != "" {
+		t.Fatalf("unexpected original contents (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapCloneFunc(t *testing.T) {
+	m := ordered.NewMap[string, []int](stdcmp.Compare)
+	m.Set("foo", []int{1})
+	m.Set("bar", []int{2, 3})
+
+	c := m.CloneFunc(slices.Clone[[]int])
+
+	// Modifying the cloned values must not affect the original.
+	c.Get("foo")[0] = 10
+	c.Set("bar", append(c.Get("bar"), 4))
+
+	want := []ordered.KeyValue[string, []int]{
+		{Key: "bar", Value: []int{2, 3}},
+		{Key: "foo", Value: []int{1}},
+	}
+
+	if diff := cmp.Diff(want, m.Range()); diff != "" {
+		t.Fatalf("unexpected original pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapWithComparator(t *testing.T) {
+	m := testMap()
+
+	// Reads okay during iteration.
+	mi := m.Iter()
+	r := m.WithComparator(ordered.Reverse(stdcmp.Compare[string]))
+	mi.Close()
+
+	want := []ordered.KeyValue[string, int]{
+		{Key: "foo", Value: 1},
+		{Key: "baz", Value: 3},
+		{Key: "bar", Value: 2},
+	}
+
+	if diff := cmp.Diff(want, r.Range()); diff != "" {
+		t.Fatalf("unexpected reordered pairs (-want +got):\n%s", diff)
+	}
+
+	// The new Map uses the new ordering and does not alias the original.
+	r.Set("qux", 4)
+	if diff := cmp.Diff([]string{"qux", "foo", "baz", "bar"}, r.Keys()); diff != "" {
+		t.Fatalf("unexpected reordered keys (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(testMap().Range(), m.Range()); diff != "" {
+		t.Fatalf("unexpected original pairs (-want +got):\n%s", diff)
+	}
+
+	if !panics(t, func() { m.WithComparator(nil) }) {
+		t.Fatal("expected nil cmp panic, but got none")
+	}
+}
+
+func TestMapSubMapCountRange(t *testing.T) {
+	m := testMap()
+
+	tests := []struct {
+		name   string
+		lo, hi string
+		want   []string
+	}{
+		{name: "all", lo: "a", hi: "zzz", want: []string{"bar", "baz", "foo"}},
+		{name: "half open", lo: "bar", hi: "foo", want: []string{"bar", "baz"}},
+		{name: "between", lo: "bb", hi: "zzz", want: []string{"foo"}},
+		{name: "equal", lo: "baz", hi: "baz", want: []string{}},
+		{name: "reversed", lo: "zzz", hi: "a", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := m.SubMap(tt.lo, tt.hi)
+			if diff := cmp.Diff(tt.want, sub.Keys()); diff != "" {
+				t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(len(tt.want), m.CountRange(tt.lo, tt.hi)); diff != "" {
+				t.Fatalf("unexpected count (-want +got):\n%s", diff)
+			}
+
+			// The output does not alias the input.
+			sub.Set("aaa", 0)
+			if diff := cmp.Diff(testMap().Range(), m.Range()); diff != "" {
+				t.Fatalf("unexpected input pairs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMapDeleteRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		lo, hi string
+		want   []string
+	}{
+		{name: "all", lo: "a", hi: "zzz", want: []string{}},
+		{name: "half open", lo: "bar", hi: "foo", want: []string{"foo"}},
+		{name: "between", lo: "bb", hi: "zzz", want: []string{"bar", "baz"}},
+		{name: "equal", lo: "baz", hi: "baz", want: []string{"bar", "baz", "foo"}},
+		{name: "reversed", lo: "zzz", hi: "a", want: []string{"bar", "baz", "foo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testMap()
+			n := m.DeleteRange(tt.lo, tt.hi)
+
+			if diff := cmp.Diff(tt.want, m.Keys()); diff != "" {
+				t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(3-len(tt.want), n); diff != "" {
+				t.Fatalf("unexpected number deleted (-want +got):\n%s", diff)
+			}
+
+			// Deleted keys must also be removed from the underlying storage.
+			for _, k := range []string{"bar", "baz", "foo"} {
+				if diff := cmp.Diff(slices.Contains(tt.want, k), m.Has(k)); diff != "" {
+					t.Fatalf("unexpected presence of key %q (-want +got):\n%s", k, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestMapHeadTailMap(t *testing.T) {
+	m := testMap()
+
+	tests := []struct {
+		name       string
+		k          string
+		head, tail []string
+	}{
+		{name: "before", k: "a", head: []string{}, tail: []string{"bar", "baz", "foo"}},
+		{name: "exact", k: "baz", head: []string{"bar"}, tail: []string{"baz", "foo"}},
+		{name: "between", k: "bb", head: []string{"bar", "baz"}, tail: []string{"foo"}},
+		{name: "after", k: "zzz", head: []string{"bar", "baz", "foo"}, tail: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head, tail := m.HeadMap(tt.k), m.TailMap(tt.k)
+			if diff := cmp.Diff(tt.head, head.Keys()); diff != "" {
+				t.Fatalf("unexpected head keys (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.tail, tail.Keys()); diff != "" {
+				t.Fatalf("unexpected tail keys (-want +got):\n%s", diff)
+			}
+
+			lower, upper := m.Split(tt.k)
+			if diff := cmp.Diff(tt.head, lower.Keys()); diff != "" {
+				t.Fatalf("unexpected lower keys (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.tail, upper.Keys()); diff != "" {
+				t.Fatalf("unexpected upper keys (-want +got):\n%s", diff)
+			}
+
+			// The outputs do not alias the input.
+			head.Set("aaa", 0)
+			tail.Set("zzzz", 0)
+			lower.Set("aaa", 0)
+			upper.Set("zzzz", 0)
+			if diff := cmp.Diff(testMap().Range(), m.Range()); diff != "" {
+				t.Fatalf("unexpected input pairs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMapMinMax(t *testing.T) {
+	m := testMap()
+
+	min, ok := m.Min()
+	if !ok {
+		t.Fatal("no minimum for non-empty map")
+	}
+
+	if diff := cmp.Diff(ordered.KeyValue[string, int]{Key: "bar", Value: 2}, min); diff != "" {
+		t.Fatalf("unexpected minimum (-want +got):\n%s", diff)
+	}
+
+	max, ok := m.Max()
+	if !ok {
+		t.Fatal("no maximum for non-empty map")
+	}
+
+	if diff := cmp.Diff(ordered.KeyValue[string, int]{Key: "foo", Value: 1}, max); diff != "" {
+		t.Fatalf("unexpected maximum (-want +got):\n%s", diff)
+	}
+
+	lo, hi, ok := m.Bounds()
+	if !ok {
+		t.Fatal("no bounds for non-empty map")
+	}
+
+	if diff := cmp.Diff([2]string{"bar", "foo"}, [2]string{lo, hi}); diff != "" {
+		t.Fatalf("unexpected bounds (-want +got):\n%s", diff)
+	}
+
+	m.Reset()
+	if _, ok := m.Min(); ok {
+		t.Fatal("minimum found for empty map")
+	}
+	if _, ok := m.Max(); ok {
+		t.Fatal("maximum found for empty map")
+	}
+	if _, _, ok := m.Bounds(); ok {
+		t.Fatal("bounds found for empty map")
+	}
+}
+
+func TestMapAtIndexOfRankOf(t *testing.T) {
+	m := testMap()
+
+	// Reads okay during iteration.
+	mi := m.Iter()
+	defer mi.Close()
+
+	for i, kv := range m.Range() {
+		if diff := cmp.Diff(kv, m.At(i)); diff != "" {
+			t.Fatalf("unexpected pair at index %d (-want +got):\n%s", i, diff)
+		}
+	}
+
+	for i, k := range m.Keys() {
+		if diff := cmp.Diff(i, m.IndexOf(k)); diff != "" {
+			t.Fatalf("unexpected index for key %q (-want +got):\n%s", k, diff)
+		}
+		if diff := cmp.Diff(i, m.RankOf(k)); diff != "" {
+			t.Fatalf("unexpected rank for key %q (-want +got):\n%s", k, diff)
+		}
+	}
+
+	// Ranks are also reported for absent keys.
+	for k, want := range map[string]int{"a": 0, "bb": 2, "zzz": 3} {
+		if diff := cmp.Diff(want, m.RankOf(k)); diff != "" {
+			t.Fatalf("unexpected rank for absent key %q (-want +got):\n%s", k, diff)
+		}
+	}
+
+	if diff := cmp.Diff(-1, m.IndexOf("notfound")); diff != "" {
+		t.Fatalf("unexpected notfound index (-want +got):\n%s", diff)
+	}
+
+	for _, i := range []int{-1, m.Len()} {
+		if !panics(t, func() { m.At(i) }) {
+			t.Fatalf("expected index %d panic, but got none", i)
+		}
+	}
+}
+
+func TestMapSelect(t *testing.T) {
+	m := testMap()
+
+	// Reads okay during iteration.
+	mi := m.Iter()
+	defer mi.Close()
+
+	kvs := m.Range()
+	for i := 0; i <= len(kvs); i++ {
+		for j := i; j <= len(kvs); j++ {
+			if diff := cmp.Diff(kvs[i:j], m.Select(i, j)); diff != "" {
+				t.Fatalf("unexpected pairs for [%d:%d] (-want +got):\n%s", i, j, diff)
+			}
+		}
+	}
+
+	for _, ij := range [][2]int{{-1, 1}, {0, 4}, {2, 1}} {
+		if !panics(t, func() { m.Select(ij[0], ij[1]) }) {
+			t.Fatalf("expected indices %v panic, but got none", ij)
+		}
+	}
+}
+
+func TestMapFloorCeiling(t *testing.T) {
+	m := testMap()
+
+	tests := []struct {
+		name        string
+		k           string
+		floor, ceil string
+		fOK, cOK    bool
+	}{
+		{name: "below", k: "a", ceil: "bar", cOK: true},
+		{name: "exact", k: "baz", floor: "baz", ceil: "baz", fOK: true, cOK: true},
+		{name: "between", k: "bb", floor: "baz", ceil: "foo", fOK: true, cOK: true},
+		{name: "above", k: "zzz", floor: "foo", fOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, fOK := m.Floor(tt.k)
+			if diff := cmp.Diff(tt.fOK, fOK); diff != "" {
+				t.Fatalf("unexpected floor OK (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.floor, f.Key); diff != "" {
+				t.Fatalf("unexpected floor key (-want +got):\n%s", diff)
+			}
+
+			c, cOK := m.Ceiling(tt.k)
+			if diff := cmp.Diff(tt.cOK, cOK); diff != "" {
+				t.Fatalf("unexpected ceiling OK (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.ceil, c.Key); diff != "" {
+				t.Fatalf("unexpected ceiling key (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMapHigherLower(t *testing.T) {
+	m := testMap()
+
+	tests := []struct {
+		name          string
+		k             string
+		higher, lower string
+		hOK, lOK      bool
+	}{
+		{name: "below", k: "a", higher: "bar", hOK: true},
+		{name: "exact", k: "baz", higher: "foo", lower: "bar", hOK: true, lOK: true},
+		{name: "between", k: "bb", higher: "foo", lower: "baz", hOK: true, lOK: true},
+		{name: "first", k: "bar", higher: "baz", hOK: true},
+		{name: "last", k: "foo", lower: "baz", lOK: true},
+		{name: "above", k: "zzz", lower: "foo", lOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, hOK := m.Higher(tt.k)
+			if diff := cmp.Diff(tt.hOK, hOK); diff != "" {
+				t.Fatalf("unexpected higher OK (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.higher, h.Key); diff != "" {
+				t.Fatalf("unexpected higher key (-want +got):\n%s", diff)
+			}
+
+			l, lOK := m.Lower(tt.k)
+			if diff := cmp.Diff(tt.lOK, lOK); diff != "" {
+				t.Fatalf("unexpected lower OK (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.lower, l.Key); diff != "" {
+				t.Fatalf("unexpected lower key (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMapNearest(t *testing.T) {
+	m := ordered.NewMap[int, string](stdcmp.Compare)
+	for _, k := range []int{10, 20, 40} {
+		m.Set(k, strconv.Itoa(k))
+	}
+
+	dist := func(a, b int) int {
+		if a < b {
+			return b - a
+		}
+		return a - b
+	}
+
+	tests := []struct {
+		name string
+		k    int
+		want int
+	}{
+		{name: "below", k: 0, want: 10},
+		{name: "exact", k: 20, want: 20},
+		{name: "closer lower", k: 24, want: 20},
+		{name: "closer higher", k: 36, want: 40},
+		{name: "tie", k: 30, want: 20},
+		{name: "above", k: 100, want: 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kv, ok := m.Nearest(tt.k, dist)
+			if !ok {
+				t.Fatal("no nearest key for non-empty map")
+			}
+
+			if diff := cmp.Diff(tt.want, kv.Key); diff != "" {
+				t.Fatalf("unexpected nearest key (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if _, ok := ordered.NewMap[int, string](stdcmp.Compare).Nearest(0, dist); ok {
+		t.Fatal("nearest key found for empty map")
+	}
+}
+
+func TestMapPop(t *testing.T) {
+	m := testMap()
+
+	v, ok := m.Pop("baz")
+	if !ok {
+		t.Fatal("baz not found")
+	}
+	if diff := cmp.Diff(3, v); diff != "" {
+		t.Fatalf("unexpected value (-want +got):\n%s", diff)
+	}
+
+	if v, ok := m.Pop("baz"); ok || v != 0 {
+		t.Fatalf("unexpected second pop: %d, %v", v, ok)
+	}
+
+	if diff := cmp.Diff([]string{"bar", "foo"}, m.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+	if m.Has("baz") {
+		t.Fatal("map still contains baz")
+	}
+}
+
+func TestMapPopMin(t *testing.T) {
+	m := testMap()
+
+	var got []ordered.KeyValue[string, int]
+	for kv, ok := m.PopMin(); ok; kv, ok = m.PopMin() {
+		got = append(got, kv)
+	}
+
+	if diff := cmp.Diff(testMap().Range(), got); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(0, m.Len()); diff != "" {
+		t.Fatalf("unexpected final length (-want +got):\n%s", diff)
+	}
+
+	// The Map remains usable after it is drained.
+	m.Set("foo", 1)
+	if diff := cmp.Diff([]string{"foo"}, m.Keys()); diff != "" {
+		t.Fatalf("unexpected final keys (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapPopMax(t *testing.T) {
+	m := testMap()
+
+	var got []ordered.KeyValue[string, int]
+	for kv, ok := m.PopMax(); ok; kv, ok = m.PopMax() {
+		got = append(got, kv)
+	}
+
+	want := testMap().Range()
+	slices.Reverse(want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(0, m.Len()); diff != "" {
+		t.Fatalf("unexpected final length (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapIterate(t *testing.T) {
+	m := testMap()
+	m.Set("zzz", 999)
+
+	var (
+		want = []string{"bar", "baz", "foo"}
+		got  []string
+	)
+
+	mi := m.Iter()
+	defer mi.Close()
+	for i, kv := 0, mi.Next(); i < 3 && kv != nil; i, kv = i+1, mi.Next() {
+		got = append(got, kv.Key)
+
+		// Reads okay during iteration.
+		if diff := cmp.Diff(kv.Value, m.Get(kv.Key)); diff != "" {
+			t.Fatalf("unexpected value for key %q (-want +got):\n%s", kv.Key, diff)
+		}
+	}
+
+	for i := 0; i < 10; i++ {
+		if mi.Next() != nil {
+			t.Fatalf("next returned non-nil for completed iterator")
+		}
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapIterateReverse(t *testing.T) {
+	m := testMap()
+
+	var (
+		want = []string{"foo", "baz", "bar"}
+		got  []string
+	)
+
+	mi := m.IterReverse()
+	for kv := mi.Next(); kv != nil; kv = mi.Next() {
+		got = append(got, kv.Key)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+
+	// Writes are permitted after close.
+	mi.Close()
+	m.Set("qux", 4)
+}
+
+func TestMapIterateFrom(t *testing.T) {
+	m := testMap()
+
+	tests := []struct {
+		name string
+		k    string
+		want []string
+	}{
+		{name: "first", k: "a", want: []string{"bar", "baz", "foo"}},
+		{name: "exact", k: "baz", want: []string{"baz", "foo"}},
+		{name: "between", k: "bb", want: []string{"foo"}},
+		{name: "last", k: "zzz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mi := m.IterFrom(tt.k)
+			defer mi.Close()
+
+			var got []string
+			for kv := mi.Next(); kv != nil; kv = mi.Next() {
+				got = append(got, kv.Key)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// All iterators are closed, so writes are permitted.
+	m.Set("qux", 4)
+}
+
+func TestMapIterateSeek(t *testing.T) {
+	m := testMap()
+
+	tests := []struct {
+		name      string
+		k         string
+		fwd, back []string
+	}{
+		{
+			name: "before",
+			k:    "a",
+			fwd:  []string{"bar", "baz", "foo"},
+		},
+		{
+			name: "exact",
+			k:    "baz",
+			fwd:  []string{"baz", "foo"},
+			back: []string{"baz", "bar"},
+		},
+		{
+			name: "between",
+			k:    "bb",
+			fwd:  []string{"foo"},
+			back: []string{"baz", "bar"},
+		},
+		{
+			name: "after",
+			k:    "zzz",
+			back: []string{"foo", "baz", "bar"},
+		},
+	}
+
+	keys := func(mi *ordered.MapIterator[string, int]) []string {
+		var keys []string
+		for kv := mi.Next(); kv != nil; kv = mi.Next() {
+			keys = append(keys, kv.Key)
+		}
+		return keys
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fwd, back := m.Iter(), m.IterReverse()
+			defer fwd.Close()
+			defer back.Close()
+
+			// Consume an element first to verify Seek can move backward.
+			_, _ = fwd.Next(), back.Next()
+			fwd.Seek(tt.k)
+			back.Seek(tt.k)
+
+			if diff := cmp.Diff(tt.fwd, keys(fwd)); diff != "" {
+				t.Fatalf("unexpected forward keys (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.back, keys(back)); diff != "" {
+				t.Fatalf("unexpected reverse keys (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMapIterateReset(t *testing.T) {
+	m := testMap()
+
+	var (
+		want = []string{"bar", "baz", "foo", "bar", "baz", "foo"}
+		got  []string
+	)
+
+	mi := m.Iter()
+	for i := 0; i < 2; i++ {
+		for kv := mi.Next(); kv != nil; kv = mi.Next() {
+			got = append(got, kv.Key)
+		}
+
+		mi.Reset()
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+
+	// Reset does not affect the open iterator count.
+	if !panics(t, func() { m.Set("panic", 0) }) {
+		t.Fatal("expected write panic after reset, but got none")
+	}
+
+	mi.Close()
+	m.Set("qux", 4)
+}
+
+func TestMapIteratePrev(t *testing.T) {
+	m := testMap()
+
+	key := func(kv *ordered.KeyValue[string, int]) string {
+		if kv == nil {
+			return "<nil>"
+		}
+		return kv.Key
+	}
+
+	tests := []struct {
+		name string
+		mi   func() *ordered.MapIterator[string, int]
+		want []string
+	}{
+		{
+			name: "forward",
+			mi:   m.Iter,
+			want: []string{"<nil>", "bar", "bar", "bar", "baz", "foo", "<nil>", "foo", "baz", "bar", "<nil>"},
+		},
+		{
+			name: "reverse",
+			mi:   m.IterReverse,
+			want: []string{"<nil>", "foo", "foo", "foo", "baz", "bar", "<nil>", "bar", "baz", "foo", "<nil>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mi := tt.mi()
+			defer mi.Close()
+
+			got := []string{
+				// Nothing before the start.
+				key(mi.Prev()),
+				// Next and Prev return the same pair.
+				key(mi.Next()),
+				key(mi.Prev()),
+			}
+
+			// Walk to the end and back again.
+			for i := 0; i < 4; i++ {
+				got = append(got, key(mi.Next()))
+			}
+			for i := 0; i < 4; i++ {
+				got = append(got, key(mi.Prev()))
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMapIterateNextOK(t *testing.T) {
+	m := testMap()
+
+	mi := m.Iter()
+	defer mi.Close()
+
+	var got []ordered.KeyValue[string, int]
+	for kv, ok := mi.NextOK(); ok; kv, ok = mi.NextOK() {
+		got = append(got, kv)
+	}
+
+	if diff := cmp.Diff(m.Range(), got); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+
+	if _, ok := mi.NextOK(); ok {
+		t.Fatal("next OK returned true for completed iterator")
+	}
+}
+
+func TestMapIterateRemove(t *testing.T) {
+	odd := func(_ string, v int) bool { return v%2 != 0 }
+
+	tests := []struct {
+		name string
+		mi   func(m *ordered.Map[string, int]) *ordered.MapIterator[string, int]
+	}{
+		{
+			name: "forward",
+			mi:   (*ordered.Map[string, int]).Iter,
+		},
+		{
+			name: "reverse",
+			mi:   (*ordered.Map[string, int]).IterReverse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := ordered.NewMap[string, int](stdcmp.Compare)
+			for i := 0; i < 10; i++ {
+				m.Set(fmt.Sprintf("%02d", i), i)
+			}
 
-	var (
-		want = []string{"bar", "baz", "foo"}
-		got  []string
-	)
+			mi := tt.mi(m)
 
-	mi := m.Iter()
-	defer mi.Close()
-	for i, kv := 0, mi.Next(); i < 3 && kv != nil; i, kv = i+1, mi.Next() {
-		got = append(got, kv.Key)
+			// Remove all the odd values while iterating.
+			var n int
+			for kv := mi.Next(); kv != nil; kv = mi.Next() {
+				n++
+				if odd(kv.Key, kv.Value) {
+					mi.Remove()
+				}
+			}
+			mi.Close()
 
-		// Reads okay during iteration.
-		if diff := cmp.Diff(kv.Value, m.Get(kv.Key)); diff != "" {
-			t.Fatalf("unexpected value for key %q (-want +got):\n%s", kv.Key, diff)
-		}
+			if diff := cmp.Diff(10, n); diff != "" {
+				t.Fatalf("unexpected number of iterations (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff([]int{0, 2, 4, 6, 8}, m.Values()); diff != "" {
+				t.Fatalf("unexpected values (-want +got):\n%s", diff)
+			}
+		})
 	}
 
-	for i := 0; i < 10; i++ {
-		if mi.Next() != nil {
-			t.Fatalf("next returned non-nil for completed iterator")
+	t.Run("prev", func(t *testing.T) {
+		m := testMap()
+		mi := m.Iter()
+		defer mi.Close()
+
+		// Skip to the end and remove the middle element on the way back.
+		for kv := mi.Next(); kv != nil; kv = mi.Next() {
 		}
-	}
+		_ = mi.Prev()
+		_ = mi.Prev()
+		mi.Remove()
 
-	if diff := cmp.Diff(want, got); diff != "" {
-		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
-	}
+		got := []string{mi.Prev().Key, mi.Next().Key, mi.Next().Key}
+		if diff := cmp.Diff([]string{"bar", "bar", "foo"}, got); diff != "" {
+			t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+		}
+	})
 }
 
 func TestMapIterateMultiple(t *testing.T) {
@@ -192,6 +1566,91 @@ func TestMapIterateEmpty(t *testing.T) {
 	}
 }
 
+func TestMapFreeze(t *testing.T) {
+	m := testMap()
+	m.Freeze()
+
+	// Reads and iteration are permitted.
+	if diff := cmp.Diff(1, m.Get("foo")); diff != "" {
+		t.Fatalf("unexpected value (-want +got):\n%s", diff)
+	}
+
+	mi := m.Iter()
+	for kv, ok := mi.NextOK(); ok; kv, ok = mi.NextOK() {
+		_ = kv
+	}
+	mi.Close()
+
+	if !panics(t, func() { m.Set("panic", 0) }) {
+		t.Fatal("expected frozen write panic, but got none")
+	}
+
+	// Clones are not frozen.
+	c := m.Clone()
+	c.Set("qux", 4)
+
+	if diff := cmp.Diff(testMap().Range(), m.Range()); diff != "" {
+		t.Fatalf("unexpected frozen pairs (-want +got):\n%s", diff)
+	}
+}
+
+func TestMapIteratorPanicRecovery(t *testing.T) {
+	t.Run("deferred close", func(t *testing.T) {
+		m := testMap()
+
+		if !panics(t, func() {
+			mi := m.Iter()
+			defer mi.Close()
+
+			for kv := mi.Next(); kv != nil; kv = mi.Next() {
+				panic("iteration panic")
+			}
+		}) {
+			t.Fatal("expected iteration panic, but got none")
+		}
+
+		// The deferred Close permits writes after recovery.
+		m.Set("qux", 4)
+	})
+
+	t.Run("reset iterators", func(t *testing.T) {
+		m := testMap()
+
+		var leaked *ordered.MapIterator[string, int]
+		if !panics(t, func() {
+			leaked = m.Iter()
+			for kv := leaked.Next(); kv != nil; kv = leaked.Next() {
+				panic("iteration panic")
+			}
+		}) {
+			t.Fatal("expected iteration panic, but got none")
+		}
+
+		if !panics(t, func() { m.Set("panic", 0) }) {
+			t.Fatal("expected write panic with leaked iterator, but got none")
+		}
+
+		m.ResetIterators()
+		m.Set("qux", 4)
+
+		// Closing the leaked iterator does not affect iterators opened after
+		// the reset.
+		mi := m.Iter()
+		leaked.Close()
+
+		if !panics(t, func() { m.Set("panic", 0) }) {
+			t.Fatal("expected write panic with open iterator, but got none")
+		}
+
+		mi.Close()
+		m.Set("aaa", 0)
+
+		if diff := cmp.Diff([]string{"aaa", "bar", "baz", "foo", "qux"}, m.Keys()); diff != "" {
+			t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+		}
+	})
+}
+
 func TestMapZeroPanics(t *testing.T) {
 	var m0 *ordered.Map[string, int]
 	if !panics(t, func() { m0.Len() }) {
@@ -206,6 +1665,27 @@ func TestMapZeroPanics(t *testing.T) {
 	if !panics(t, func() { ordered.NewMap[string, int](nil) }) {
 		t.Fatal("expected nil less panic, but got none")
 	}
+
+	if !panics(t, func() { ordered.NewMapWithCapacity[string, int](nil, 1) }) {
+		t.Fatal("expected capacity nil less panic, but got none")
+	}
+
+	if !panics(t, func() { ordered.NewMapWithCapacity[string, int](stdcmp.Compare, -1) }) {
+		t.Fatal("expected negative capacity panic, but got none")
+	}
+
+	if !panics(t, func() { ordered.NewMapOpts(ordered.WithCapacity[string, int](1)) }) {
+		t.Fatal("expected options nil cmp panic, but got none")
+	}
+
+	if !panics(t, func() {
+		ordered.NewMapOpts(
+			ordered.WithCompareFunc[string, int](stdcmp.Compare),
+			ordered.WithCapacity[string, int](-1),
+		)
+	}) {
+		t.Fatal("expected options negative capacity panic, but got none")
+	}
 }
 
 func TestMapMethodPanics(t *testing.T) {
@@ -221,6 +1701,31 @@ func TestMapMethodPanics(t *testing.T) {
 				mi.Close()
 			},
 		},
+		{
+			name: "iter next after close",
+			fn: func(m *ordered.Map[string, int]) {
+				mi := m.Iter()
+				mi.Close()
+				mi.Next()
+			},
+		},
+		{
+			name: "iter seek after close",
+			fn: func(m *ordered.Map[string, int]) {
+				mi := m.Iter()
+				mi.Close()
+				mi.Seek("foo")
+			},
+		},
+		{
+			name: "iter close twice with another open",
+			fn: func(m *ordered.Map[string, int]) {
+				_ = m.Iter()
+				mi := m.Iter()
+				mi.Close()
+				mi.Close()
+			},
+		},
 		{
 			name: "iter set",
 			fn: func(m *ordered.Map[string, int]) {
@@ -228,6 +1733,41 @@ func TestMapMethodPanics(t *testing.T) {
 				m.Set("panic", 0)
 			},
 		},
+		{
+			name: "iter reverse set",
+			fn: func(m *ordered.Map[string, int]) {
+				_ = m.IterReverse()
+				m.Set("panic", 0)
+			},
+		},
+		{
+			name: "iter get or set",
+			fn: func(m *ordered.Map[string, int]) {
+				_ = m.Iter()
+				m.GetOrSet("foo", 0)
+			},
+		},
+		{
+			name: "iter set if absent",
+			fn: func(m *ordered.Map[string, int]) {
+				_ = m.Iter()
+				m.SetIfAbsent("foo", 0)
+			},
+		},
+		{
+			name: "iter update",
+			fn: func(m *ordered.Map[string, int]) {
+				_ = m.Iter()
+				m.Update("foo", func(v int, _ bool) int { return v })
+			},
+		},
+		{
+			name: "iter merge",
+			fn: func(m *ordered.Map[string, int]) {
+				_ = m.Iter()
+				m.Merge(testMap())
+			},
+		},
 		{
 			name: "iter delete",
 			fn: func(m *ordered.Map[string, int]) {
@@ -235,6 +1775,70 @@ func TestMapMethodPanics(t *testing.T) {
 				m.Delete("panic")
 			},
 		},
+		{
+			name: "iter delete range",
+			fn: func(m *ordered.Map[string, int]) {
+				_ = m.Iter()
+				m.DeleteRange("a", "z")
+			},
+		},
+		{
+			name: "iter pop",
+			fn: func(m *ordered.Map[string, int]) {
+				_ = m.Iter()
+				m.Pop("foo")
+			},
+		},
+		{
+			name: "iter set many",
+			fn: func(m *ordered.Map[string, int]) {
+				_ = m.Iter()
+				m.SetMany(ordered.KeyValue[string, int]{Key: "panic"})
+			},
+		},
+		{
+			name: "iter set all",
+			fn: func(m *ordered.Map[string, int]) {
+				_ = m.Iter()
+				m.SetAll(map[string]int{"panic": 0})
+			},
+		},
+		{
+			name: "iter grow",
+			fn: func(m *ordered.Map[string, int]) {
+				_ = m.Iter()
+				m.Grow(1)
+			},
+		},
+		{
+			name: "iter compact",
+			fn: func(m *ordered.Map[string, int]) {
+				_ = m.Iter()
+				m.Compact()
+			},
+		},
+		{
+			name: "grow negative",
+			fn:   func(m *ordered.Map[string, int]) { m.Grow(-1) },
+		},
+		{
+			name: "chunk zero",
+			fn:   func(m *ordered.Map[string, int]) { m.Chunk(0) },
+		},
+		{
+			name: "iter pop min",
+			fn: func(m *ordered.Map[string, int]) {
+				_ = m.Iter()
+				m.PopMin()
+			},
+		},
+		{
+			name: "iter pop max",
+			fn: func(m *ordered.Map[string, int]) {
+				_ = m.Iter()
+				m.PopMax()
+			},
+		},
 		{
 			name: "iter reset",
 			fn: func(m *ordered.Map[string, int]) {
@@ -242,6 +1846,13 @@ func TestMapMethodPanics(t *testing.T) {
 				m.Reset()
 			},
 		},
+		{
+			name: "iter clear",
+			fn: func(m *ordered.Map[string, int]) {
+				_ = m.Iter()
+				m.Clear()
+			},
+		},
 		{
 			name: "iter nil",
 			fn: func(_ *ordered.Map[string, int]) {
@@ -249,6 +1860,36 @@ func TestMapMethodPanics(t *testing.T) {
 				mi.Next()
 			},
 		},
+		{
+			name: "iter nil seek",
+			fn: func(_ *ordered.Map[string, int]) {
+				var mi *ordered.MapIterator[string, int]
+				mi.Seek("panic")
+			},
+		},
+		{
+			name: "iter nil reset",
+			fn: func(_ *ordered.Map[string, int]) {
+				var mi *ordered.MapIterator[string, int]
+				mi.Reset()
+			},
+		},
+		{
+			name: "iter remove without next",
+			fn: func(m *ordered.Map[string, int]) {
+				mi := m.Iter()
+				mi.Remove()
+			},
+		},
+		{
+			name: "iter remove twice",
+			fn: func(m *ordered.Map[string, int]) {
+				mi := m.Iter()
+				mi.Next()
+				mi.Remove()
+				mi.Remove()
+			},
+		},
 		{
 			name: "iter zero",
 			fn: func(_ *ordered.Map[string, int]) {
@@ -256,6 +1897,56 @@ func TestMapMethodPanics(t *testing.T) {
 				mi.Next()
 			},
 		},
+		{
+			name: "frozen set",
+			fn: func(m *ordered.Map[string, int]) {
+				m.Freeze()
+				m.Set("panic", 0)
+			},
+		},
+		{
+			name: "frozen delete",
+			fn: func(m *ordered.Map[string, int]) {
+				m.Freeze()
+				m.Delete("foo")
+			},
+		},
+		{
+			name: "frozen reset",
+			fn: func(m *ordered.Map[string, int]) {
+				m.Freeze()
+				m.Reset()
+			},
+		},
+		{
+			name: "frozen iter remove",
+			fn: func(m *ordered.Map[string, int]) {
+				m.Freeze()
+				mi := m.Iter()
+				mi.Next()
+				mi.Remove()
+			},
+		},
+		{
+			name: "iter remove with another iter open",
+			fn: func(m *ordered.Map[string, int]) {
+				_ = m.Iter()
+				mi := m.Iter()
+				mi.Next()
+				mi.Remove()
+			},
+		},
+		{
+			name: "iter remove during callback",
+			fn: func(m *ordered.Map[string, int]) {
+				mi := m.Iter()
+				mi.Next()
+				m.ForEach(func(string, int) bool {
+					mi.Remove()
+					return false
+				})
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -268,6 +1959,109 @@ func TestMapMethodPanics(t *testing.T) {
 	}
 }
 
+func BenchmarkMapSet(b *testing.B) {
+	for _, n := range []int{10, 1000, 10000} {
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				m := ordered.NewMap[int, int](stdcmp.Compare)
+				for j := 0; j < n; j++ {
+					// Insert keys out of order to exercise sorted insertion.
+					k := (j * 7919) % n
+					m.Set(k, j)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMapPriorityQueue(b *testing.B) {
+	for _, n := range []int{10, 1000, 10000} {
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+
+			m := ordered.NewMapWithCapacity[int, int](stdcmp.Compare, n)
+			for i := 0; i < b.N; i++ {
+				// Fill and then drain the queue, so the cost per operation can
+				// be computed by dividing by 2n.
+				for j := 0; j < n; j++ {
+					m.Push((j*7919)%n, j)
+				}
+				for m.Len() > 0 {
+					m.PopMin()
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMapIterator(b *testing.B) {
+	m := benchMap(100_000)
+
+	b.Run("Next", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			mi := m.Iter()
+			for kv := mi.Next(); kv != nil; kv = mi.Next() {
+			}
+			mi.Close()
+		}
+	})
+
+	b.Run("NextOK", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			mi := m.Iter()
+			for _, ok := mi.NextOK(); ok; _, ok = mi.NextOK() {
+			}
+			mi.Close()
+		}
+	})
+}
+
+func BenchmarkMapRange(b *testing.B) {
+	m := benchMap(100_000)
+
+	b.Run("Range", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			for range m.Range() {
+			}
+		}
+	})
+
+	b.Run("Values", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_ = m.Values()
+		}
+	})
+
+	b.Run("ForEach", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			m.ForEach(func(int, int) bool { return true })
+		}
+	})
+}
+
+func benchMap(n int) *ordered.Map[int, int] {
+	kvs := make([]ordered.KeyValue[int, int], 0, n)
+	for i := 0; i < n; i++ {
+		kvs = append(kvs, ordered.KeyValue[int, int]{Key: i, Value: i})
+	}
+
+	m := ordered.NewMap[int, int](stdcmp.Compare)
+	m.SetMany(kvs...)
+	return m
+}
+
 func testMap() *ordered.Map[string, int] {
 	m := ordered.NewMap[string, int](stdcmp.Compare)
 	m.Set("foo", 1)
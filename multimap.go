@@ -0,0 +1,68 @@
+package ordered
+
+// A MultiMap is like a Map, but stores multiple values V for each key K. Keys
+// are ordered by applying a comparison function, and the values for each key
+// are stored in insertion order. A MultiMap must be constructed using
+// NewMultiMap or its methods will panic.
+//
+// MultiMaps are not safe for concurrent use.
+type MultiMap[K comparable, V any] struct {
+	m *Map[K, []V]
+}
+
+// NewMultiMap creates a *MultiMap[K, V] which uses a comparison function to
+// order the keys in the map. See NewMap for details.
+func NewMultiMap[K comparable, V any](cmp func(a, b K) int) *MultiMap[K, V] {
+	if cmp == nil {
+		panic("ordered: NewMultiMap must use a non-nil cmp function")
+	}
+
+	return &MultiMap[K, V]{m: NewMap[K, []V](cmp)}
+}
+
+// Add appends the value V to the values for a given key K.
+func (mm *MultiMap[K, V]) Add(k K, v V) {
+	mm.check()
+	mm.m.Set(k, append(mm.m.Get(k), v))
+}
+
+// Get gets the values for a given key K in insertion order, returning nil if K
+// is not found. The returned slice is owned by the MultiMap and must not be
+// modified.
+func (mm *MultiMap[K, V]) Get(k K) []V {
+	mm.check()
+	return mm.m.Get(k)
+}
+
+// Delete deletes all of the values for a given key K.
+func (mm *MultiMap[K, V]) Delete(k K) {
+	mm.check()
+	mm.m.Delete(k)
+}
+
+// Len returns the number of distinct keys in the MultiMap.
+func (mm *MultiMap[K, V]) Len() int {
+	mm.check()
+	return mm.m.Len()
+}
+
+// Range produces a slice of all keys and their values from MultiMap for use in
+// a for range loop. See MultiMap.Iter for more fine-grained iteration control.
+func (mm *MultiMap[K, V]) Range() []KeyValue[K, []V] {
+	mm.check()
+	return mm.m.Range()
+}
+
+// Iter produces a MapIterator which allows fine-grained iteration over the keys
+// and values of a MultiMap. See MapIterator for details.
+func (mm *MultiMap[K, V]) Iter() *MapIterator[K, []V] {
+	mm.check()
+	return mm.m.Iter()
+}
+
+// check checks the MultiMap's invariants.
+func (mm *MultiMap[K, V]) check() {
+	if mm == nil || mm.m == nil {
+		panic("ordered: a MultiMap must be constructed using NewMultiMap")
+	}
+}
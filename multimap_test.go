@@ -0,0 +1,61 @@
+package ordered_test
+
+import (
+	stdcmp "cmp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mdlayher/ordered"
+)
+
+func TestMultiMap(t *testing.T) {
+	mm := ordered.NewMultiMap[string, int](stdcmp.Compare)
+	mm.Add("foo", 1)
+	mm.Add("bar", 2)
+	mm.Add("foo", 3)
+	mm.Add("baz", 4)
+	mm.Add("foo", 2)
+
+	if diff := cmp.Diff(3, mm.Len()); diff != "" {
+		t.Fatalf("unexpected length (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]int{1, 3, 2}, mm.Get("foo")); diff != "" {
+		t.Fatalf("unexpected foo values (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int(nil), mm.Get("notfound")); diff != "" {
+		t.Fatalf("unexpected notfound values (-want +got):\n%s", diff)
+	}
+
+	mm.Delete("baz")
+
+	want := []ordered.KeyValue[string, []int]{
+		{Key: "bar", Value: []int{2}},
+		{Key: "foo", Value: []int{1, 3, 2}},
+	}
+
+	if diff := cmp.Diff(want, mm.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+
+	mi := mm.Iter()
+
+	var got []ordered.KeyValue[string, []int]
+	for kv := mi.Next(); kv != nil; kv = mi.Next() {
+		got = append(got, *kv)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected iterated pairs (-want +got):\n%s", diff)
+	}
+
+	if !panics(t, func() { mm.Add("panic", 0) }) {
+		t.Fatal("expected write panic during iteration, but got none")
+	}
+	mi.Close()
+
+	var zero ordered.MultiMap[string, int]
+	if !panics(t, func() { zero.Len() }) {
+		t.Fatal("expected zero multimap panic, but got none")
+	}
+}
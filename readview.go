@@ -0,0 +1,117 @@
+package ordered
+
+// A ReadView is a read-only view of a Map. A ReadView has no methods which
+// write to the Map, but it does reflect later writes made to the Map through
+// other means. A ReadView must be constructed using Map.ReadOnly or its
+// methods will panic.
+type ReadView[K comparable, V any] struct {
+	m *Map[K, V]
+}
+
+// ReadOnly produces a ReadView of m which can be passed to code that must not
+// write to m.
+func (m *Map[K, V]) ReadOnly() ReadView[K, V] {
+	m.check(ro)
+	return ReadView[K, V]{m: m}
+}
+
+// Get gets the value V for a given key K. See Map.Get for details.
+func (rv ReadView[K, V]) Get(k K) V {
+	rv.check()
+	return rv.m.Get(k)
+}
+
+// TryGet gets the value V for a given key K. See Map.TryGet for details.
+func (rv ReadView[K, V]) TryGet(k K) (V, bool) {
+	rv.check()
+	return rv.m.TryGet(k)
+}
+
+// Has reports whether the key K is present in the Map.
+func (rv ReadView[K, V]) Has(k K) bool {
+	rv.check()
+	return rv.m.Has(k)
+}
+
+// Len returns the number of elements in the Map.
+func (rv ReadView[K, V]) Len() int {
+	rv.check()
+	return rv.m.Len()
+}
+
+// Range produces a slice of all KeyValue pairs from the Map. See Map.Range for
+// details.
+func (rv ReadView[K, V]) Range() []KeyValue[K, V] {
+	rv.check()
+	return rv.m.Range()
+}
+
+// Iter produces a ReadIterator which allows fine-grained iteration over the
+// Map. See Map.Iter for details.
+func (rv ReadView[K, V]) Iter() *ReadIterator[K, V] {
+	rv.check()
+	return &ReadIterator[K, V]{mi: rv.m.Iter()}
+}
+
+// check checks the ReadView's invariants.
+func (rv ReadView[K, V]) check() {
+	if rv.m == nil {
+		panic("ordered: a ReadView must be constructed using Map.ReadOnly")
+	}
+}
+
+// A ReadIterator is a MapIterator without the ability to remove KeyValue pairs
+// from a Map. A ReadIterator must be constructed using ReadView.Iter or its
+// methods will panic.
+//
+// A ReadIterator follows the same rules as a MapIterator: any methods which
+// write to a Map will panic until ReadIterator.Close is called.
+type ReadIterator[K comparable, V any] struct {
+	mi *MapIterator[K, V]
+}
+
+// Close releases a ReadIterator's resources, enabling further writes to a Map.
+func (ri *ReadIterator[K, V]) Close() {
+	ri.check()
+	ri.mi.Close()
+}
+
+// Next returns the next KeyValue pair from a Map. See MapIterator.Next for
+// details.
+func (ri *ReadIterator[K, V]) Next() *KeyValue[K, V] {
+	ri.check()
+	return ri.mi.Next()
+}
+
+// NextOK is like Next, but returns the next KeyValue pair by value. See
+// MapIterator.NextOK for details.
+func (ri *ReadIterator[K, V]) NextOK() (KeyValue[K, V], bool) {
+	ri.check()
+	return ri.mi.NextOK()
+}
+
+// Prev returns the previous KeyValue pair from a Map. See MapIterator.Prev for
+// details.
+func (ri *ReadIterator[K, V]) Prev() *KeyValue[K, V] {
+	ri.check()
+	return ri.mi.Prev()
+}
+
+// Seek repositions a ReadIterator. See MapIterator.Seek for details.
+func (ri *ReadIterator[K, V]) Seek(k K) {
+	ri.check()
+	ri.mi.Seek(k)
+}
+
+// Reset rewinds a ReadIterator. See MapIterator.Reset for details.
+func (ri *ReadIterator[K, V]) Reset() {
+	ri.check()
+	ri.mi.Reset()
+}
+
+// check checks the ReadIterator's invariants.
+func (ri *ReadIterator[K, V]) check() {
+	if ri == nil || ri.mi == nil {
+		panic("ordered: a ReadIterator must be constructed using ReadView.Iter")
+	}
+}
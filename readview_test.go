@@ -0,0 +1,85 @@
+package ordered_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mdlayher/ordered"
+)
+
+func TestReadView(t *testing.T) {
+	m := testMap()
+	rv := m.ReadOnly()
+
+	if diff := cmp.Diff(2, rv.Get("bar")); diff != "" {
+		t.Fatalf("unexpected value (-want +got):\n%s", diff)
+	}
+	if _, ok := rv.TryGet("notfound"); ok {
+		t.Fatal("view contains notfound")
+	}
+	if !rv.Has("foo") {
+		t.Fatal("view does not contain foo")
+	}
+
+	// The view reflects later writes to the Map.
+	m.Set("qux", 4)
+	m.Delete("bar")
+
+	if diff := cmp.Diff(3, rv.Len()); diff != "" {
+		t.Fatalf("unexpected length (-want +got):\n%s", diff)
+	}
+
+	want := []ordered.KeyValue[string, int]{
+		{Key: "baz", Value: 3},
+		{Key: "foo", Value: 1},
+		{Key: "qux", Value: 4},
+	}
+
+	if diff := cmp.Diff(want, rv.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+
+	ri := rv.Iter()
+
+	var got []ordered.KeyValue[string, int]
+	for kv, ok := ri.NextOK(); ok; kv, ok = ri.NextOK() {
+		got = append(got, kv)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected iterated pairs (-want +got):\n%s", diff)
+	}
+
+	ri.Seek("foo")
+	if diff := cmp.Diff(&want[1], ri.Next()); diff != "" {
+		t.Fatalf("unexpected pair after seek (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(&want[1], ri.Prev()); diff != "" {
+		t.Fatalf("unexpected previous pair (-want +got):\n%s", diff)
+	}
+
+	ri.Reset()
+	if diff := cmp.Diff(&want[0], ri.Next()); diff != "" {
+		t.Fatalf("unexpected pair after reset (-want +got):\n%s", diff)
+	}
+
+	if !panics(t, func() { m.Set("panic", 0) }) {
+		t.Fatal("expected write panic during iteration, but got none")
+	}
+
+	// Writes are permitted after close.
+	ri.Close()
+	m.Set("aaa", 0)
+}
+
+func TestReadViewZeroPanics(t *testing.T) {
+	var rv ordered.ReadView[string, int]
+	if !panics(t, func() { rv.Len() }) {
+		t.Fatal("expected zero view panic, but got none")
+	}
+
+	var ri *ordered.ReadIterator[string, int]
+	if !panics(t, func() { ri.Next() }) {
+		t.Fatal("expected nil iterator panic, but got none")
+	}
+}
@@ -0,0 +1,180 @@
+package ordered
+
+// A Set is a set of unique elements K which offers deterministic iteration
+// order by applying a comparison function against all elements. A Set must be
+// constructed using NewSet or its methods will panic.
+//
+// A Set is built on a Map, so the same iteration rules apply: see MapIterator
+// for details. Sets are not safe for concurrent use.
+type Set[K comparable] struct {
+	m *Map[K, struct{}]
+}
+
+// NewSet creates a *Set[K] which uses a comparison function to order the
+// elements in the set. cmp must not be nil or NewSet will panic. For types
+// which meet the [cmp.Ordered] constraint, [cmp.Compare] can be used as a
+// comparison function.
+func NewSet[K comparable](cmp func(a, b K) int) *Set[K] {
+	if cmp == nil {
+		panic("ordered: NewSet must use a non-nil cmp function")
+	}
+
+	return &Set[K]{m: NewMap[K, struct{}](cmp)}
+}
+
+// Add inserts the element K into the Set.
+func (s *Set[K]) Add(k K) {
+	s.check()
+	s.m.Set(k, struct{}{})
+}
+
+// Remove removes the element K from the Set.
+func (s *Set[K]) Remove(k K) {
+	s.check()
+	s.m.Delete(k)
+}
+
+// Contains reports whether the element K is present in the Set.
+func (s *Set[K]) Contains(k K) bool {
+	s.check()
+	return s.m.Has(k)
+}
+
+// Len returns the number of elements in the Set.
+func (s *Set[K]) Len() int {
+	s.check()
+	return s.m.Len()
+}
+
+// Range produces a slice of all elements from Set in sorted order for use in a
+// for range loop. See Set.Iter for more fine-grained iteration control.
+func (s *Set[K]) Range() []K {
+	s.check()
+	return s.m.Keys()
+}
+
+// Union produces a new Set containing the elements present in either s or
+// other. Union produces a Set which uses the comparison function from s.
+//
+// Because both Sets are already sorted, Union merges them in linear time.
+// Therefore, other must be ordered by a comparison function equivalent to the
+// one used by s, or the resulting Set's order is undefined.
+func (s *Set[K]) Union(other *Set[K]) *Set[K] {
+	return s.merge(other, true, true, true)
+}
+
+// Intersection produces a new Set containing the elements present in both s
+// and other. See Set.Union for details on ordering requirements.
+func (s *Set[K]) Intersection(other *Set[K]) *Set[K] {
+	return s.merge(other, false, true, false)
+}
+
+// Difference produces a new Set containing the elements present in s but not
+// in other. See Set.Union for details on ordering requirements.
+func (s *Set[K]) Difference(other *Set[K]) *Set[K] {
+	return s.merge(other, true, false, false)
+}
+
+// merge merges the sorted elements of s and other in linear time, keeping the
+// elements only present in s, present in both, or only present in other
+// according to the input flags.
+func (s *Set[K]) merge(other *Set[K], onlyS, both, onlyOther bool) *Set[K] {
+	s.check()
+	other.check()
+	s.m.check(ro)
+	other.m.check(ro)
+
+	var (
+		cmp  = s.m.cmp
+		a, b = s.m.keys, other.m.keys
+		out  = make([]K, 0, len(a)+len(b))
+	)
+
+	for len(a) > 0 && len(b) > 0 {
+		switch c := cmp(a[0], b[0]); {
+		case c < 0:
+			if onlyS {
+				out = append(out, a[0])
+			}
+			a = a[1:]
+		case c > 0:
+			if onlyOther {
+				out = append(out, b[0])
+			}
+			b = b[1:]
+		default:
+			if both {
+				out = append(out, a[0])
+			}
+			a, b = a[1:], b[1:]
+		}
+	}
+
+	if onlyS {
+		out = append(out, a...)
+	}
+	if onlyOther {
+		out = append(out, b...)
+	}
+
+	// The output is already sorted, so populate the Set directly.
+	m := NewMapWithCapacity[K, struct{}](cmp, len(out))
+	m.keys = out
+	m.vals = make([]struct{}, len(out))
+	for _, k := range out {
+		m.m[k] = struct{}{}
+	}
+
+	return &Set[K]{m: m}
+}
+
+// check checks the Set's invariants.
+func (s *Set[K]) check() {
+	if s == nil || s.m == nil {
+		panic("ordered: a Set must be constructed using NewSet")
+	}
+}
+
+// A SetIterator is an iteration cursor over a Set. A SetIterator must be
+// constructed using Set.Iter or its methods will panic.
+//
+// A SetIterator follows the same rules as a MapIterator: any methods which
+// write to a Set will panic until SetIterator.Close is called.
+type SetIterator[K comparable] struct {
+	mi *MapIterator[K, struct{}]
+}
+
+// Iter produces a SetIterator which allows fine-grained iteration over a Set.
+func (s *Set[K]) Iter() *SetIterator[K] {
+	s.check()
+	return &SetIterator[K]{mi: s.m.Iter()}
+}
+
+// Close releases a SetIterator's resources, enabling further writes to a Set.
+func (si *SetIterator[K]) Close() {
+	si.check()
+	si.mi.Close()
+}
+
+// Next returns the next element from a Set. If Next returns false, no more
+// elements are present. Next is intended to be used in a for loop, in the
+// format:
+//
+//	si := s.Iter()
+//	defer si.Close()
+//	for k, ok := si.Next(); ok; k, ok = si.Next() {
+//	    // use k
+//	}
+func (si *SetIterator[K]) Next() (K, bool) {
+	si.check()
+
+	kv, ok := si.mi.NextOK()
+	return kv.Key, ok
+}
+
+// check checks the SetIterator's invariants.
+func (si *SetIterator[K]) check() {
+	if si == nil || si.mi == nil {
+		panic("ordered: a SetIterator must be constructed using Set.Iter")
+	}
+}
@@ -0,0 +1,168 @@
+package ordered_test
+
+import (
+	stdcmp "cmp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mdlayher/ordered"
+)
+
+func TestSetBasics(t *testing.T) {
+	s := testSet("foo", "bar", "baz", "foo")
+
+	if diff := cmp.Diff(3, s.Len()); diff != "" {
+		t.Fatalf("unexpected initial length (-want +got):\n%s", diff)
+	}
+
+	if !s.Contains("foo") {
+		t.Fatal("set does not contain foo")
+	}
+	if s.Contains("notfound") {
+		t.Fatal("set contains notfound")
+	}
+
+	s.Remove("bar")
+	s.Remove("notfound")
+
+	if diff := cmp.Diff([]string{"baz", "foo"}, s.Range()); diff != "" {
+		t.Fatalf("unexpected elements (-want +got):\n%s", diff)
+	}
+}
+
+func TestSetIterate(t *testing.T) {
+	s := testSet("foo", "bar", "baz")
+
+	si := s.Iter()
+
+	var got []string
+	for k, ok := si.Next(); ok; k, ok = si.Next() {
+		got = append(got, k)
+	}
+
+	if diff := cmp.Diff([]string{"bar", "baz", "foo"}, got); diff != "" {
+		t.Fatalf("unexpected elements (-want +got):\n%s", diff)
+	}
+
+	if !panics(t, func() { s.Add("panic") }) {
+		t.Fatal("expected write panic during iteration, but got none")
+	}
+
+	// Writes are permitted after close.
+	si.Close()
+	s.Add("qux")
+}
+
+func TestSetOperations(t *testing.T) {
+	var (
+		a = testSet("a", "b", "c", "d")
+		b = testSet("c", "d", "e")
+	)
+
+	tests := []struct {
+		name string
+		fn   func(a, b *ordered.Set[string]) *ordered.Set[string]
+		want []string
+	}{
+		{
+			name: "union",
+			fn:   (*ordered.Set[string]).Union,
+			want: []string{"a", "b", "c", "d", "e"},
+		},
+		{
+			name: "intersection",
+			fn:   (*ordered.Set[string]).Intersection,
+			want: []string{"c", "d"},
+		},
+		{
+			name: "difference",
+			fn:   (*ordered.Set[string]).Difference,
+			want: []string{"a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.fn(a, b)
+			if diff := cmp.Diff(tt.want, got.Range()); diff != "" {
+				t.Fatalf("unexpected elements (-want +got):\n%s", diff)
+			}
+
+			// The output is a fully functional Set.
+			got.Add("0")
+			if !got.Contains("0") {
+				t.Fatal("output set does not contain added element")
+			}
+		})
+	}
+
+	// The inputs are unmodified.
+	if diff := cmp.Diff([]string{"a", "b", "c", "d"}, a.Range()); diff != "" {
+		t.Fatalf("unexpected a elements (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"c", "d", "e"}, b.Range()); diff != "" {
+		t.Fatalf("unexpected b elements (-want +got):\n%s", diff)
+	}
+}
+
+func TestSetZeroPanics(t *testing.T) {
+	var s0 *ordered.Set[string]
+	if !panics(t, func() { s0.Len() }) {
+		t.Fatal("expected nil set panic, but got none")
+	}
+
+	var s1 ordered.Set[string]
+	if !panics(t, func() { s1.Len() }) {
+		t.Fatal("expected zero set panic, but got none")
+	}
+
+	var si ordered.SetIterator[string]
+	if !panics(t, func() { si.Next() }) {
+		t.Fatal("expected zero set iterator panic, but got none")
+	}
+
+	if !panics(t, func() { ordered.NewSet[string](nil) }) {
+		t.Fatal("expected nil cmp panic, but got none")
+	}
+}
+
+func BenchmarkSetUnion(b *testing.B) {
+	const n = 10_000
+
+	x, y := ordered.NewSet[int](stdcmp.Compare), ordered.NewSet[int](stdcmp.Compare)
+	for i := 0; i < n; i++ {
+		x.Add(i * 2)
+		y.Add(i * 3)
+	}
+
+	b.Run("merge", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_ = x.Union(y)
+		}
+	})
+
+	b.Run("naive", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			s := ordered.NewSet[int](stdcmp.Compare)
+			for _, k := range x.Range() {
+				s.Add(k)
+			}
+			for _, k := range y.Range() {
+				s.Add(k)
+			}
+		}
+	})
+}
+
+func testSet(ks ...string) *ordered.Set[string] {
+	s := ordered.NewSet[string](stdcmp.Compare)
+	for _, k := range ks {
+		s.Add(k)
+	}
+
+	return s
+}
@@ -0,0 +1,67 @@
+package ordered
+
+import "sync"
+
+// A SyncMap is like a Map, but is safe for concurrent use by multiple
+// goroutines. A SyncMap must be constructed using NewSyncMap or its methods
+// will panic.
+//
+// SyncMap serializes all access to an internal Map using a sync.RWMutex. The
+// internal Map is never exposed, so iteration is performed over a snapshot of
+// the SyncMap's KeyValue pairs produced by SyncMap.Range.
+type SyncMap[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  *Map[K, V]
+}
+
+// NewSyncMap creates a *SyncMap[K, V] which uses a comparison function to order
+// the keys in the map. See NewMap for details.
+func NewSyncMap[K comparable, V any](cmp func(a, b K) int) *SyncMap[K, V] {
+	return &SyncMap[K, V]{m: NewMap[K, V](cmp)}
+}
+
+// Get gets the value V for a given key K, returning the zero value of V if K is
+// not found.
+func (sm *SyncMap[K, V]) Get(k K) V {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.m.Get(k)
+}
+
+// TryGet tries to get the value V for a given key K, returning false if K is
+// not found.
+func (sm *SyncMap[K, V]) TryGet(k K) (V, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.m.TryGet(k)
+}
+
+// Len returns the number of elements in the SyncMap.
+func (sm *SyncMap[K, V]) Len() int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.m.Len()
+}
+
+// Set inserts or updates the value V for a given key K.
+func (sm *SyncMap[K, V]) Set(k K, v V) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.m.Set(k, v)
+}
+
+// Delete deletes the value for a given key K.
+func (sm *SyncMap[K, V]) Delete(k K) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.m.Delete(k)
+}
+
+// Range produces a snapshot of all KeyValue pairs from SyncMap for use in a for
+// range loop. The snapshot is consistent at the time of the call, and the
+// SyncMap may be modified while the snapshot is in use.
+func (sm *SyncMap[K, V]) Range() []KeyValue[K, V] {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.m.Range()
+}
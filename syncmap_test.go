@@ -0,0 +1,51 @@
+package ordered_test
+
+import (
+	stdcmp "cmp"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mdlayher/ordered"
+)
+
+func TestSyncMap(t *testing.T) {
+	const n = 8
+	m := ordered.NewSyncMap[int, int](stdcmp.Compare)
+
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+
+			// Interleave reads and writes with other goroutines.
+			m.Set(i, i)
+			m.Set(-i-1, i)
+			_ = m.Get(i)
+			_, _ = m.TryGet(i)
+			_ = m.Len()
+
+			for _, kv := range m.Range() {
+				// The SyncMap may be modified during iteration.
+				m.Delete(kv.Key - n)
+			}
+
+			m.Delete(-i - 1)
+		}(i)
+	}
+	wg.Wait()
+
+	want := make([]ordered.KeyValue[int, int], 0, n)
+	for i := 0; i < n; i++ {
+		want = append(want, ordered.KeyValue[int, int]{Key: i, Value: i})
+	}
+
+	if diff := cmp.Diff(want, m.Range()); diff != "" {
+		t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(n, m.Len()); diff != "" {
+		t.Fatalf("unexpected length (-want +got):\n%s", diff)
+	}
+}